@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"shopping-site/api/services"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AddressHandler struct {
+	services.IAddressService
+}
+
+func (service *AddressHandler) GetAddressesHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	addresses, errResponse := service.IAddressService.GetAddressesService(userIdCtx)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: addresses,
+	})
+}
+
+func (service *AddressHandler) GetAddressHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	address, errResponse := service.IAddressService.GetAddressService(userIdCtx, ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: address,
+	})
+}
+
+func (service *AddressHandler) AddAddressHandler(ctx *fiber.Ctx) error {
+	var address models.Addresses
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&address); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IAddressService.AddAddressService(userIdCtx, &address)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "address added successfully",
+		Data:    address,
+	})
+}
+
+func (service *AddressHandler) UpdateAddressHandler(ctx *fiber.Ctx) error {
+	var addressRequest dto.AddressUpdateRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&addressRequest); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	address, errResponse := service.IAddressService.UpdateAddressService(userIdCtx, ctx.Params("id"), addressRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "address updated successfully",
+		Data:    address,
+	})
+}
+
+func (service *AddressHandler) DeleteAddressHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IAddressService.DeleteAddressService(userIdCtx, id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "address deleted successfully",
+		Data:    map[string]interface{}{"address_id": id},
+	})
+}
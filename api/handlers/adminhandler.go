@@ -3,58 +3,372 @@ package handlers
 import (
 	"shopping-site/api/services"
 	"shopping-site/pkg/loggers"
-	"shopping-site/pkg/models"
 	"shopping-site/utils/dto"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type AdminHandler struct {
 	services.IAdminService
+	services.IAuthService
 }
 
-func (service *AdminHandler) AddCategoreyHandler(ctx *fiber.Ctx) error {
-	var category models.Categories
+func (service *AdminHandler) UpdateOrderStatusHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	orderStatus := ctx.Query("order_status")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	if err := ctx.BodyParser(&category); err != nil {
+	errResponse := service.IAdminService.UpdateOrderStatusService(userIdCtx, id, orderStatus)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "order status updated successfully",
+		Data:    map[string]interface{}{"order_id": id},
+	})
+}
+
+func (service *AdminHandler) GetProductsHandler(ctx *fiber.Ctx) error {
+	var filter dto.ProductFilter
+
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	products, errResponse := service.IAdminService.GetProductsService(filter)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: products,
+	})
+}
+
+func (service *AdminHandler) GetPendingProductsHandler(ctx *fiber.Ctx) error {
+	products, errResponse := service.IAdminService.GetPendingProductsService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: products,
+	})
+}
+
+func (service *AdminHandler) ApproveProductHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.IAdminService.ApproveProductService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "product approved successfully",
+		Data:    map[string]interface{}{"product_id": id},
+	})
+}
+
+func (service *AdminHandler) RejectProductHandler(ctx *fiber.Ctx) error {
+	var rejectRequest dto.RejectRequest
+	id := ctx.Params("id")
+
+	if err := ctx.BodyParser(&rejectRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IAdminService.RejectProductService(id, rejectRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "product rejected successfully",
+		Data:    map[string]interface{}{"product_id": id},
+	})
+}
+
+func (service *AdminHandler) GetTwoFactorPoliciesHandler(ctx *fiber.Ctx) error {
+	policies, errResponse := service.IAdminService.GetTwoFactorPoliciesService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: policies,
+	})
+}
+
+func (service *AdminHandler) UpdateTwoFactorPolicyHandler(ctx *fiber.Ctx) error {
+	var policyRequest dto.TwoFactorPolicyRequest
+
+	if err := ctx.BodyParser(&policyRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	policy, errResponse := service.IAdminService.UpdateTwoFactorPolicyService(policyRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "two-factor policy updated successfully",
+		Data:    policy,
+	})
+}
+
+func (service *AdminHandler) UnlockAccountHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.IAdminService.UnlockAccountService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "account unlocked successfully",
+		Data:    map[string]interface{}{"user_id": id},
+	})
+}
+
+func (service *AdminHandler) GetPermissionsHandler(ctx *fiber.Ctx) error {
+	permissions, errResponse := service.IAdminService.GetPermissionsService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: permissions,
+	})
+}
+
+func (service *AdminHandler) GrantPermissionHandler(ctx *fiber.Ctx) error {
+	var permissionRequest dto.RolePermissionRequest
+	role := ctx.Params("role")
+
+	if err := ctx.BodyParser(&permissionRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IAdminService.GrantPermissionService(role, permissionRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "permission granted successfully",
+		Data:    map[string]interface{}{"role": role, "permission": permissionRequest.Permission},
+	})
+}
+
+func (service *AdminHandler) RevokePermissionHandler(ctx *fiber.Ctx) error {
+	role := ctx.Params("role")
+	permission := ctx.Params("permission")
+
+	errResponse := service.IAdminService.RevokePermissionService(role, permission)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "permission revoked successfully",
+		Data:    map[string]interface{}{"role": role, "permission": permission},
+	})
+}
+
+func (service *AdminHandler) GetUsersHandler(ctx *fiber.Ctx) error {
+	var filter dto.UserFilter
+
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	users, errResponse := service.IAdminService.GetUsersService(filter)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: users,
+	})
+}
+
+func (service *AdminHandler) GetUserHandler(ctx *fiber.Ctx) error {
+	user, errResponse := service.IAdminService.GetUserService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: user,
+	})
+}
+
+func (service *AdminHandler) GetUserOrdersHandler(ctx *fiber.Ctx) error {
+	orders, errResponse := service.IAdminService.GetUserOrdersService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: orders,
+	})
+}
+
+func (service *AdminHandler) GetUserAddressesHandler(ctx *fiber.Ctx) error {
+	addresses, errResponse := service.IAdminService.GetUserAddressesService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: addresses,
+	})
+}
+
+func (service *AdminHandler) SuspendUserHandler(ctx *fiber.Ctx) error {
+	var suspendRequest dto.SuspendRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&suspendRequest); err != nil {
 		loggers.WarnLog.Println(err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
 			Error: err.Error(),
 		})
 	}
 
-	errResponse := service.IAdminService.AddCategoreyService(&category)
+	errResponse := service.IAdminService.SuspendUserService(userIdCtx, id, suspendRequest)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
 		})
 	}
 
-	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
-		Message: "Category created successfully",
-		Data:    category,
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "account suspended successfully",
+		Data:    map[string]interface{}{"user_id": id},
 	})
 }
 
-func (service *AdminHandler) AddBrandHandler(ctx *fiber.Ctx) error {
-	var brand models.Brands
+func (service *AdminHandler) ReactivateUserHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
 
-	if err := ctx.BodyParser(&brand); err != nil {
+	errResponse := service.IAdminService.ReactivateUserService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "account reactivated successfully",
+		Data:    map[string]interface{}{"user_id": id},
+	})
+}
+
+func (service *AdminHandler) ChangeUserRoleHandler(ctx *fiber.Ctx) error {
+	var roleRequest dto.RoleRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&roleRequest); err != nil {
 		loggers.WarnLog.Println(err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
 			Error: err.Error(),
 		})
 	}
 
-	errResponse := service.IAdminService.AddBrandService(&brand)
+	errResponse := service.IAdminService.ChangeUserRoleService(userIdCtx, id, roleRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "role updated successfully",
+		Data:    map[string]interface{}{"user_id": id, "role": roleRequest.Role},
+	})
+}
+
+func (service *AdminHandler) ForcePasswordResetHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.IAuthService.ForcePasswordResetService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "password reset email sent successfully",
+		Data:    map[string]interface{}{"user_id": id},
+	})
+}
+
+func (service *AdminHandler) DeleteUserHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IAdminService.DeleteUserService(userIdCtx, id)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
 		})
 	}
 
-	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
-		Message: "Brand created successfully",
-		Data:    brand,
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "account deleted successfully",
+		Data:    map[string]interface{}{"user_id": id},
 	})
 }
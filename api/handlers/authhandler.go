@@ -1,16 +1,15 @@
 package handlers
 
 import (
-	"os"
 	"shopping-site/api/services"
 	"shopping-site/api/validation"
 	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
 	"shopping-site/utils/dto"
-	"time"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type AuthHandler struct {
@@ -40,6 +39,18 @@ func (handler *AuthHandler) SignupHandler(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{Message: "User Created"})
 }
 
+func setRetryAfter(ctx *fiber.Ctx, errResponse *dto.ErrorResponse) {
+	if errResponse.Status != fiber.StatusTooManyRequests {
+		return
+	}
+
+	if data, ok := errResponse.Data.(map[string]interface{}); ok {
+		if retryAfter, ok := data["retry_after"].(int); ok {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		}
+	}
+}
+
 func (handler *AuthHandler) LoginHandler(ctx *fiber.Ctx) error {
 	var loginRequest dto.LoginRequest
 
@@ -55,37 +66,263 @@ func (handler *AuthHandler) LoginHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	user, errResponse := handler.IAuthService.LoginService(loginRequest)
+	user, errResponse := handler.IAuthService.LoginService(loginRequest, ctx.IP())
+	if errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		setRetryAfter(ctx, errResponse)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error, Data: errResponse.Data})
+	}
+
+	if user.TwoFactorEnabled {
+		challengeToken, errResponse := handler.IAuthService.CreateTwoFactorChallengeService(user)
+		if errResponse != nil {
+			loggers.ErrorLog.Println(errResponse.Error)
+			return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+		}
+
+		return ctx.Status(fiber.StatusAccepted).JSON(dto.ResponseJson{
+			Message: "Two-factor authentication required",
+			Data:    map[string]interface{}{"challenge_token": challengeToken},
+		})
+	}
+
+	return handler.startSession(ctx, user, false, loginRequest.ReturnToken)
+}
+
+func (handler *AuthHandler) TwoFactorLoginHandler(ctx *fiber.Ctx) error {
+	var loginRequest dto.TwoFactorLoginRequest
+
+	if err := ctx.BodyParser(&loginRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
+	}
+
+	user, errResponse := handler.IAuthService.VerifyTwoFactorLoginService(loginRequest, ctx.IP())
+	if errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		setRetryAfter(ctx, errResponse)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error, Data: errResponse.Data})
+	}
+
+	return handler.startSession(ctx, user, true, loginRequest.ReturnToken)
+}
+
+func (handler *AuthHandler) EnrollTwoFactorHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	enrollment, errResponse := handler.IAuthService.EnrollTwoFactorService(userIdCtx)
 	if errResponse != nil {
 		loggers.ErrorLog.Println(errResponse.Error)
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
 	}
 
-	claims := &dto.JWTClaims{
-		UserID: user.UserId,
-		Email:  user.Email,
-		Role:   user.Role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 2)),
-		},
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "Scan the provisioning uri with an authenticator app and confirm with a code",
+		Data:    enrollment,
+	})
+}
+
+func (handler *AuthHandler) ConfirmTwoFactorHandler(ctx *fiber.Ctx) error {
+	var codeRequest dto.TwoFactorCodeRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+	sessionIdCtx := ctx.Locals("session_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&codeRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	recoveryCodes, errResponse := handler.IAuthService.ConfirmTwoFactorService(userIdCtx, sessionIdCtx, codeRequest)
+	if errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "Two-factor authentication enabled, store the recovery codes safely",
+		Data:    map[string]interface{}{"recovery_codes": recoveryCodes},
+	})
+}
+
+func (handler *AuthHandler) DisableTwoFactorHandler(ctx *fiber.Ctx) error {
+	var codeRequest dto.TwoFactorCodeRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET_KEY")))
-	if err != nil {
+	if err := ctx.BodyParser(&codeRequest); err != nil {
 		loggers.WarnLog.Println(err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
 	}
 
-	cookie := fiber.Cookie{
+	if errResponse := handler.IAuthService.DisableTwoFactorService(userIdCtx, codeRequest); errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Two-factor authentication disabled"})
+}
+
+func (handler *AuthHandler) startSession(ctx *fiber.Ctx, user *models.Users, twoFactorVerified bool, returnToken bool) error {
+	tokens, errResponse := handler.IAuthService.CreateSessionService(user, twoFactorVerified)
+	if errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	if returnToken {
+		return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{Message: "Logged in successfully", Data: tokens})
+	}
+
+	csrfToken := setAuthCookies(ctx, tokens)
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "Logged in successfully",
+		Data:    map[string]interface{}{"csrf_token": csrfToken},
+	})
+}
+
+func (handler *AuthHandler) RefreshHandler(ctx *fiber.Ctx) error {
+	var refreshRequest dto.RefreshRequest
+
+	refreshToken := ctx.Cookies("refresh_token")
+	if refreshToken == "" {
+		if err := ctx.BodyParser(&refreshRequest); err != nil {
+			loggers.WarnLog.Println(err)
+			return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
+		}
+	}
+
+	if refreshRequest.RefreshToken != "" {
+		refreshToken = refreshRequest.RefreshToken
+	}
+
+	tokens, errResponse := handler.IAuthService.RefreshService(refreshToken)
+	if errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		clearAuthCookies(ctx)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	if refreshRequest.RefreshToken != "" {
+		return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Token refreshed successfully", Data: tokens})
+	}
+
+	csrfToken := setAuthCookies(ctx, tokens)
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "Token refreshed successfully",
+		Data:    map[string]interface{}{"csrf_token": csrfToken},
+	})
+}
+
+func (handler *AuthHandler) LogoutHandler(ctx *fiber.Ctx) error {
+	sessionIdCtx := ctx.Locals("session_id").(uuid.UUID)
+
+	if errResponse := handler.IAuthService.LogoutService(sessionIdCtx); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	clearAuthCookies(ctx)
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Logged out successfully"})
+}
+
+func (handler *AuthHandler) LogoutAllHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if errResponse := handler.IAuthService.LogoutAllService(userIdCtx); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	clearAuthCookies(ctx)
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Logged out from all sessions successfully"})
+}
+
+func (handler *AuthHandler) VerifyEmailHandler(ctx *fiber.Ctx) error {
+	if errResponse := handler.IAuthService.VerifyEmailService(ctx.Query("token")); errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Email verified successfully"})
+}
+
+func (handler *AuthHandler) ResendVerificationHandler(ctx *fiber.Ctx) error {
+	var emailRequest dto.EmailRequest
+
+	if err := ctx.BodyParser(&emailRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
+	}
+
+	if errResponse := handler.IAuthService.ResendVerificationService(emailRequest); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "If the account exists and is unverified, a verification email has been sent"})
+}
+
+func (handler *AuthHandler) ForgotPasswordHandler(ctx *fiber.Ctx) error {
+	var emailRequest dto.EmailRequest
+
+	if err := ctx.BodyParser(&emailRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
+	}
+
+	if errResponse := handler.IAuthService.ForgotPasswordService(emailRequest); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "If the account exists, a password reset email has been sent"})
+}
+
+func (handler *AuthHandler) ResetPasswordHandler(ctx *fiber.Ctx) error {
+	var resetRequest dto.PasswordResetRequest
+
+	if err := ctx.BodyParser(&resetRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{Error: err.Error()})
+	}
+
+	if errResponse := handler.IAuthService.ResetPasswordService(resetRequest); errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{Error: errResponse.Error})
+	}
+
+	clearAuthCookies(ctx)
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{Message: "Password reset successfully, please login again"})
+}
+
+func setAuthCookies(ctx *fiber.Ctx, tokens *dto.AuthTokens) string {
+	csrfToken := uuid.NewString()
+
+	ctx.Cookie(&fiber.Cookie{
 		Name:     "jwt",
-		Value:    tokenString,
-		Expires:  time.Now().Add(time.Hour * 24),
+		Value:    tokens.AccessToken,
+		Expires:  tokens.AccessExpiresAt,
 		HTTPOnly: true,
-	}
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
 
-	ctx.Cookie(&cookie)
-	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{Message: "Logged in successfully"})
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    tokens.RefreshToken,
+		Expires:  tokens.RefreshExpiresAt,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     "csrf_token",
+		Value:    csrfToken,
+		Expires:  tokens.RefreshExpiresAt,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	return csrfToken
+}
 
+func clearAuthCookies(ctx *fiber.Ctx) {
+	ctx.ClearCookie("jwt", "refresh_token", "csrf_token")
 }
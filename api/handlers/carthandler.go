@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"shopping-site/api/services"
+	"shopping-site/pkg/loggers"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CartHandler struct {
+	services.ICartService
+}
+
+func (service *CartHandler) GetCartHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	cart, errResponse := service.ICartService.GetCartService(userIdCtx)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: cart,
+	})
+}
+
+func (service *CartHandler) AddCartItemHandler(ctx *fiber.Ctx) error {
+	var item dto.CartItemRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&item); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.ICartService.AddCartItemService(userIdCtx, item)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "product added to cart successfully",
+	})
+}
+
+func (service *CartHandler) UpdateCartItemHandler(ctx *fiber.Ctx) error {
+	var item dto.CartItemRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&item); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.ICartService.UpdateCartItemService(userIdCtx, id, item)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "cart updated successfully",
+	})
+}
+
+func (service *CartHandler) RemoveCartItemHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.ICartService.RemoveCartItemService(userIdCtx, id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "product removed from cart successfully",
+	})
+}
+
+func (service *CartHandler) ClearCartHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.ICartService.ClearCartService(userIdCtx)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "cart cleared successfully",
+	})
+}
+
+func (service *CartHandler) CheckoutHandler(ctx *fiber.Ctx) error {
+	var checkout dto.CheckoutRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&checkout); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	orderDetails, errResponse := service.ICartService.CheckoutService(userIdCtx, checkout)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+			Data:  errResponse.Data,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "order placed successfully",
+		Data:    orderDetails,
+	})
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"shopping-site/api/services"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CatalogHandler struct {
+	services.ICatalogService
+}
+
+func (service *CatalogHandler) AddCategoryHandler(ctx *fiber.Ctx) error {
+	var category models.Categories
+
+	if err := ctx.BodyParser(&category); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.ICatalogService.AddCategoryService(&category)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "category created successfully",
+		Data:    category,
+	})
+}
+
+func (service *CatalogHandler) GetCategoriesHandler(ctx *fiber.Ctx) error {
+	categorys, errResponse := service.ICatalogService.GetCategoriesService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: categorys,
+	})
+}
+
+func (service *CatalogHandler) GetCategoryHandler(ctx *fiber.Ctx) error {
+	category, errResponse := service.ICatalogService.GetCategoryService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: category,
+	})
+}
+
+func (service *CatalogHandler) UpdateCategoryHandler(ctx *fiber.Ctx) error {
+	var categoryRequest dto.CategoryUpdateRequest
+	id := ctx.Params("id")
+
+	if err := ctx.BodyParser(&categoryRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	category, errResponse := service.ICatalogService.UpdateCategoryService(id, categoryRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "category updated successfully",
+		Data:    category,
+	})
+}
+
+func (service *CatalogHandler) DeleteCategoryHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.ICatalogService.DeleteCategoryService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "category deleted successfully",
+		Data:    map[string]interface{}{"category_id": id},
+	})
+}
+
+func (service *CatalogHandler) GetCategoryTreeHandler(ctx *fiber.Ctx) error {
+	categories, errResponse := service.ICatalogService.GetCategoryTreeService(ctx.Params("slug"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: categories,
+	})
+}
+
+func (service *CatalogHandler) AddBrandHandler(ctx *fiber.Ctx) error {
+	var brand models.Brands
+
+	if err := ctx.BodyParser(&brand); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.ICatalogService.AddBrandService(&brand)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "brand created successfully",
+		Data:    brand,
+	})
+}
+
+func (service *CatalogHandler) GetBrandsHandler(ctx *fiber.Ctx) error {
+	brands, errResponse := service.ICatalogService.GetBrandsService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: brands,
+	})
+}
+
+func (service *CatalogHandler) GetBrandHandler(ctx *fiber.Ctx) error {
+	brand, errResponse := service.ICatalogService.GetBrandService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: brand,
+	})
+}
+
+func (service *CatalogHandler) UpdateBrandHandler(ctx *fiber.Ctx) error {
+	var brandRequest dto.BrandUpdateRequest
+	id := ctx.Params("id")
+
+	if err := ctx.BodyParser(&brandRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	brand, errResponse := service.ICatalogService.UpdateBrandService(id, brandRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "brand updated successfully",
+		Data:    brand,
+	})
+}
+
+func (service *CatalogHandler) DeleteBrandHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.ICatalogService.DeleteBrandService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "brand deleted successfully",
+		Data:    map[string]interface{}{"brand_id": id},
+	})
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"shopping-site/api/services"
 	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
@@ -78,18 +77,43 @@ func (service *MerchantHandler) UpdateProductHandler(ctx *fiber.Ctx) error {
 	})
 }
 
+func (service *MerchantHandler) UpdateStockHandler(ctx *fiber.Ctx) error {
+	var stockRequest dto.StockRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&stockRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IMerchantService.UpdateStockService(userIdCtx, id, stockRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "product stock updated successfully",
+		Data:    map[string]interface{}{"product_id": id, "stock": *stockRequest.Stock},
+	})
+}
+
 func (service *MerchantHandler) UpdateMerchantHandler(ctx *fiber.Ctx) error {
-	var user models.Users
+	var profileRequest dto.ProfileUpdateRequest
 	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	if err := ctx.BodyParser(&user); err != nil {
+	if err := ctx.BodyParser(&profileRequest); err != nil {
 		loggers.WarnLog.Println(err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
 			Error: err.Error(),
 		})
 	}
 
-	errResponse := service.IMerchantService.UpdateMerchantService(userIdCtx, &user)
+	errResponse := service.IMerchantService.UpdateMerchantService(userIdCtx, profileRequest)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -98,17 +122,44 @@ func (service *MerchantHandler) UpdateMerchantHandler(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
 		Message: "user details updated successfully",
-		Data:    map[string]interface{}{"user_id": user.UserId},
+		Data:    map[string]interface{}{"user_id": userIdCtx},
+	})
+}
+
+func (service *MerchantHandler) ChangePasswordHandler(ctx *fiber.Ctx) error {
+	var passwordRequest dto.ChangePasswordRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+	sessionIdCtx := ctx.Locals("session_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&passwordRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IMerchantService.ChangePasswordService(userIdCtx, sessionIdCtx, passwordRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "password changed successfully",
 	})
 }
 
 func (service *MerchantHandler) UpdateOrderStatusHandler(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
-	orderStatus := ctx.Query("order_status")
-	fmt.Println("**", orderStatus)
+	update := dto.ShipmentUpdate{
+		Status:         ctx.Query("order_status"),
+		TrackingNumber: ctx.Query("tracking_number"),
+		Carrier:        ctx.Query("carrier"),
+	}
 	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	errResponse := service.IMerchantService.UpdateOrderStatusService(userIdCtx, id, orderStatus)
+	errResponse := service.IMerchantService.UpdateOrderStatusService(userIdCtx, id, update)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -122,11 +173,17 @@ func (service *MerchantHandler) UpdateOrderStatusHandler(ctx *fiber.Ctx) error {
 }
 
 func (service *MerchantHandler) GetProductsHandler(ctx *fiber.Ctx) error {
+	var filter dto.ProductFilter
 	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	filters := ctx.Queries()
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
 
-	products, errResponse := service.IMerchantService.GetProductsService(filters, userIdCtx)
+	products, errResponse := service.IMerchantService.GetProductsService(userIdCtx, filter)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -157,7 +214,9 @@ func (service *MerchantHandler) GetProductHandler(ctx *fiber.Ctx) error {
 func (service *MerchantHandler) GetOrdersHandler(ctx *fiber.Ctx) error {
 	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	orders, errResponse := service.IMerchantService.GetOrdersService(userIdCtx)
+	filters := ctx.Queries()
+
+	orders, errResponse := service.IMerchantService.GetOrdersService(userIdCtx, filters)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"shopping-site/api/services"
+	"shopping-site/pkg/loggers"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type OnboardingHandler struct {
+	services.IOnboardingService
+}
+
+func (service *OnboardingHandler) ApplyMerchantHandler(ctx *fiber.Ctx) error {
+	var applicationRequest dto.MerchantApplicationRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&applicationRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	application, errResponse := service.IOnboardingService.ApplyMerchantService(userIdCtx, applicationRequest, form.File["documents"])
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "merchant application submitted successfully",
+		Data:    application,
+	})
+}
+
+func (service *OnboardingHandler) GetUserApplicationsHandler(ctx *fiber.Ctx) error {
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	applications, errResponse := service.IOnboardingService.GetUserApplicationsService(userIdCtx)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: applications,
+	})
+}
+
+func (service *OnboardingHandler) GetMerchantApplicationsHandler(ctx *fiber.Ctx) error {
+	applications, errResponse := service.IOnboardingService.GetMerchantApplicationsService(ctx.Query("status"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: applications,
+	})
+}
+
+func (service *OnboardingHandler) GetMerchantApplicationHandler(ctx *fiber.Ctx) error {
+	application, errResponse := service.IOnboardingService.GetMerchantApplicationService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: application,
+	})
+}
+
+func (service *OnboardingHandler) GetMerchantDocumentHandler(ctx *fiber.Ctx) error {
+	document, filePath, errResponse := service.IOnboardingService.GetMerchantDocumentService(ctx.Params("id"), ctx.Params("documentId"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.ContentType)
+	return ctx.Download(filePath, document.FileName)
+}
+
+func (service *OnboardingHandler) ApproveMerchantApplicationHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IOnboardingService.ApproveMerchantApplicationService(userIdCtx, id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "merchant application approved successfully",
+		Data:    map[string]interface{}{"application_id": id},
+	})
+}
+
+func (service *OnboardingHandler) RejectMerchantApplicationHandler(ctx *fiber.Ctx) error {
+	var rejectRequest dto.RejectRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&rejectRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IOnboardingService.RejectMerchantApplicationService(userIdCtx, id, rejectRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "merchant application rejected successfully",
+		Data:    map[string]interface{}{"application_id": id},
+	})
+}
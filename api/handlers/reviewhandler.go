@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"net/url"
+	"shopping-site/api/services"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReviewHandler struct {
+	services.IReviewService
+}
+
+func (service *ReviewHandler) GetProductReviewsHandler(ctx *fiber.Ctx) error {
+	reviews, errResponse := service.IReviewService.GetProductReviewsService(ctx.Params("id"))
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: reviews,
+	})
+}
+
+func (service *ReviewHandler) AddReviewHandler(ctx *fiber.Ctx) error {
+	var review models.Reviews
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&review); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IReviewService.AddReviewService(userIdCtx, ctx.Params("id"), &review)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "review added successfully",
+		Data:    review,
+	})
+}
+
+func (service *ReviewHandler) UpdateReviewHandler(ctx *fiber.Ctx) error {
+	var review models.Reviews
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&review); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IReviewService.UpdateReviewService(userIdCtx, ctx.Params("id"), &review)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "review updated successfully",
+		Data:    review,
+	})
+}
+
+func (service *ReviewHandler) DeleteReviewHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IReviewService.DeleteReviewService(userIdCtx, id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "review deleted successfully",
+		Data:    map[string]interface{}{"review_id": id},
+	})
+}
+
+func (service *ReviewHandler) ReplyReviewHandler(ctx *fiber.Ctx) error {
+	var replyRequest dto.ReviewReplyRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&replyRequest); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	review, errResponse := service.IReviewService.ReplyReviewService(userIdCtx, ctx.Params("id"), replyRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "reply added successfully",
+		Data:    review,
+	})
+}
+
+func (service *ReviewHandler) ReportReviewHandler(ctx *fiber.Ctx) error {
+	var reportRequest dto.ReportRequest
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&reportRequest); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IReviewService.ReportReviewService(userIdCtx, id, reportRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "review reported successfully",
+		Data:    map[string]interface{}{"review_id": id},
+	})
+}
+
+func (service *ReviewHandler) GetModerationQueueHandler(ctx *fiber.Ctx) error {
+	reviews, errResponse := service.IReviewService.GetModerationQueueService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: reviews,
+	})
+}
+
+func (service *ReviewHandler) ApproveReviewHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IReviewService.ModerateReviewService(userIdCtx, id, constants.Published)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "review approved successfully",
+		Data:    map[string]interface{}{"review_id": id},
+	})
+}
+
+func (service *ReviewHandler) HideReviewHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+
+	errResponse := service.IReviewService.ModerateReviewService(userIdCtx, id, constants.Hidden)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "review hidden successfully",
+		Data:    map[string]interface{}{"review_id": id},
+	})
+}
+
+func (service *ReviewHandler) RemoveReviewHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	errResponse := service.IReviewService.RemoveReviewService(id)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "review deleted successfully",
+		Data:    map[string]interface{}{"review_id": id},
+	})
+}
+
+func (service *ReviewHandler) GetBannedWordsHandler(ctx *fiber.Ctx) error {
+	words, errResponse := service.IReviewService.GetBannedWordsService()
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: words,
+	})
+}
+
+func (service *ReviewHandler) AddBannedWordHandler(ctx *fiber.Ctx) error {
+	var wordRequest dto.BannedWordRequest
+
+	if err := ctx.BodyParser(&wordRequest); err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	word, errResponse := service.IReviewService.AddBannedWordService(wordRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(dto.ResponseJson{
+		Message: "banned word added successfully",
+		Data:    word,
+	})
+}
+
+func (service *ReviewHandler) DeleteBannedWordHandler(ctx *fiber.Ctx) error {
+	word, err := url.PathUnescape(ctx.Params("word"))
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IReviewService.DeleteBannedWordService(word)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "banned word removed successfully",
+		Data:    map[string]interface{}{"word": word},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
 	"shopping-site/utils/dto"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -29,6 +30,7 @@ func (service *UserHandler) PlaceOrderHandler(ctx *fiber.Ctx) error {
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
+			Data:  errResponse.Data,
 		})
 	}
 
@@ -55,17 +57,17 @@ func (service *UserHandler) CancelOrderHandler(ctx *fiber.Ctx) error {
 }
 
 func (service *UserHandler) UpdateUserHandler(ctx *fiber.Ctx) error {
-	var user models.Users
+	var profileRequest dto.ProfileUpdateRequest
 	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
 
-	if err := ctx.BodyParser(&user); err != nil {
+	if err := ctx.BodyParser(&profileRequest); err != nil {
 		loggers.WarnLog.Println(err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
 			Error: err.Error(),
 		})
 	}
 
-	errResponse := service.IUserService.UpdateUserService(userIdCtx, &user)
+	errResponse := service.IUserService.UpdateUserService(userIdCtx, profileRequest)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -74,7 +76,31 @@ func (service *UserHandler) UpdateUserHandler(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
 		Message: "user details updated successfully",
-		Data:    map[string]interface{}{"user_id": user.UserId},
+		Data:    map[string]interface{}{"user_id": userIdCtx},
+	})
+}
+
+func (service *UserHandler) ChangePasswordHandler(ctx *fiber.Ctx) error {
+	var passwordRequest dto.ChangePasswordRequest
+	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+	sessionIdCtx := ctx.Locals("session_id").(uuid.UUID)
+
+	if err := ctx.BodyParser(&passwordRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	errResponse := service.IUserService.ChangePasswordService(userIdCtx, sessionIdCtx, passwordRequest)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Message: "password changed successfully",
 	})
 }
 
@@ -94,11 +120,50 @@ func (service *UserHandler) GetOrdersHandler(ctx *fiber.Ctx) error {
 }
 
 func (service *UserHandler) GetProductsHandler(ctx *fiber.Ctx) error {
-	userIdCtx := ctx.Locals("user_id").(uuid.UUID)
+	var filter dto.ProductFilter
+
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	products, errResponse := service.IUserService.GetProductsService(filter)
+	if errResponse != nil {
+		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+			Error: errResponse.Error,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
+		Data: products,
+	})
+}
 
-	filters := ctx.Queries()
+func (service *UserHandler) FilterProductsHandler(ctx *fiber.Ctx) error {
+	var filter dto.ProductFilter
 
-	products, errResponse := service.IUserService.GetProductsService(filters, userIdCtx)
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	if price := ctx.Query("price"); price != "" && filter.MinPrice == 0 {
+		minPrice, err := strconv.ParseFloat(price, 64)
+		if err != nil {
+			loggers.WarnLog.Println(err)
+			return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+				Error: "price should be a number",
+			})
+		}
+
+		filter.MinPrice = minPrice
+	}
+
+	products, errResponse := service.IUserService.GetProductsService(filter)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -126,10 +191,17 @@ func (service *UserHandler) GetProductHandler(ctx *fiber.Ctx) error {
 	})
 }
 
-func (service *UserHandler) FilterProductsHandler(ctx *fiber.Ctx) error {
-	filters := ctx.Queries()
+func (service *UserHandler) SearchProductsHandler(ctx *fiber.Ctx) error {
+	var filter dto.ProductSearchFilter
 
-	products, errResponse := service.IUserService.FilterProductsService(filters)
+	if err := ctx.QueryParser(&filter); err != nil {
+		loggers.WarnLog.Println(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(dto.ResponseJson{
+			Error: err.Error(),
+		})
+	}
+
+	results, errResponse := service.IUserService.SearchProductsService(filter)
 	if errResponse != nil {
 		return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
 			Error: errResponse.Error,
@@ -137,6 +209,6 @@ func (service *UserHandler) FilterProductsHandler(ctx *fiber.Ctx) error {
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(dto.ResponseJson{
-		Data: products,
+		Data: results,
 	})
 }
@@ -3,58 +3,123 @@ package middleware
 import (
 	"fmt"
 	"os"
+	"shopping-site/api/repositories"
 	"shopping-site/pkg/loggers"
 	"shopping-site/utils/dto"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func ValidateJwt(ctx *fiber.Ctx) error {
-	tokenString := ctx.Cookies("jwt")
-	if tokenString == "" {
-		loggers.WarnLog.Println("Login required to proceed")
-		return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
-			Message: "Login required to proceed",
-			Error:   "unauthorized request",
-		})
-	}
+func ValidateJwt(authRepository repositories.IAuthRepository) fiber.Handler {
+	return validateJwt(authRepository, true)
+}
 
-	token, err := jwt.ParseWithClaims(tokenString, &dto.JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+func ValidateJwtForEnrollment(authRepository repositories.IAuthRepository) fiber.Handler {
+	return validateJwt(authRepository, false)
+}
+
+func validateJwt(authRepository repositories.IAuthRepository, enforceTwoFactor bool) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		tokenString := ctx.Cookies("jwt")
+		bearerToken, hasBearer := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if hasBearer {
+			tokenString = strings.TrimSpace(bearerToken)
 		}
 
-		return []byte(os.Getenv("SECRET_KEY")), nil
-	})
+		if tokenString == "" {
+			loggers.WarnLog.Println("Login required to proceed")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Message: "Login required to proceed",
+				Error:   "unauthorized request",
+			})
+		}
 
-	if err != nil {
-		loggers.WarnLog.Println(err)
-		return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
-			Message: "invalid token",
-			Error:   err.Error(),
-		})
-	}
+		if !hasBearer && !isSafeMethod(ctx.Method()) && !validCsrfToken(ctx) {
+			loggers.WarnLog.Println("invalid csrf token")
+			return ctx.Status(fiber.StatusForbidden).JSON(dto.ResponseJson{
+				Error: "invalid csrf token",
+			})
+		}
 
-	claims, ok := token.Claims.(*dto.JWTClaims)
-	if !ok {
-		loggers.WarnLog.Println(err)
-		return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
-			Error: "invalid token",
-		})
-	}
+		token, err := jwt.ParseWithClaims(tokenString, &dto.JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
 
-	if time.Now().Unix() > claims.ExpiresAt.Unix() {
-		fmt.Println("The claims exp ", claims.ExpiresAt.Unix())
-		return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
-			Message: "session expired,please login again to proceed",
+			return []byte(os.Getenv("SECRET_KEY")), nil
 		})
-	}
 
-	ctx.Locals("user_id", claims.UserID)
-	ctx.Locals("role", claims.Role)
-	ctx.Locals("email", claims.Email)
+		if err != nil {
+			loggers.WarnLog.Println(err)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Message: "invalid token",
+				Error:   err.Error(),
+			})
+		}
+
+		claims, ok := token.Claims.(*dto.JWTClaims)
+		if !ok {
+			loggers.WarnLog.Println(err)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Error: "invalid token",
+			})
+		}
+
+		if time.Now().Unix() > claims.ExpiresAt.Unix() {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Message: "session expired,please login again to proceed",
+			})
+		}
+
+		session, errResponse := authRepository.ValidateSessionRepository(claims.SessionId)
+		if errResponse != nil {
+			loggers.WarnLog.Println(errResponse.Error)
+			return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+				Error: errResponse.Error,
+			})
+		}
+
+		user, errResponse := authRepository.GetUserByIdRepository(claims.UserID)
+		if errResponse != nil {
+			loggers.WarnLog.Println(errResponse.Error)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Error: "invalid session",
+			})
+		}
 
-	return ctx.Next()
+		if user.SuspendedAt != nil {
+			loggers.WarnLog.Println("account suspended")
+			return ctx.Status(fiber.StatusForbidden).JSON(dto.ResponseJson{
+				Error: "account suspended",
+			})
+		}
+
+		if enforceTwoFactor && !session.TwoFactorVerified {
+			required, errResponse := authRepository.IsTwoFactorRequiredRepository(claims.Role)
+			if errResponse != nil {
+				loggers.ErrorLog.Println(errResponse.Error)
+				return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+					Error: errResponse.Error,
+				})
+			}
+
+			if required {
+				loggers.WarnLog.Println("two-factor authentication required")
+				return ctx.Status(fiber.StatusForbidden).JSON(dto.ResponseJson{
+					Message: "enroll in two-factor authentication to proceed",
+					Error:   "two-factor authentication required",
+				})
+			}
+		}
+
+		ctx.Locals("user_id", claims.UserID)
+		ctx.Locals("role", claims.Role)
+		ctx.Locals("email", claims.Email)
+		ctx.Locals("session_id", claims.SessionId)
+
+		return ctx.Next()
+	}
 }
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"shopping-site/pkg/loggers"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func isSafeMethod(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
+
+func validCsrfToken(ctx *fiber.Ctx) bool {
+	cookieToken := ctx.Cookies("csrf_token")
+	headerToken := ctx.Get("X-CSRF-Token")
+	if cookieToken == "" || headerToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) == 1
+}
+
+func ValidateCsrf(ctx *fiber.Ctx) error {
+	if isSafeMethod(ctx.Method()) || ctx.Get(fiber.HeaderAuthorization) != "" {
+		return ctx.Next()
+	}
+
+	if ctx.Cookies("jwt") == "" && ctx.Cookies("refresh_token") == "" {
+		return ctx.Next()
+	}
+
+	if !validCsrfToken(ctx) {
+		loggers.WarnLog.Println("invalid csrf token")
+		return ctx.Status(fiber.StatusForbidden).JSON(dto.ResponseJson{
+			Error: "invalid csrf token",
+		})
+	}
+
+	return ctx.Next()
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"shopping-site/api/repositories"
+	"shopping-site/pkg/loggers"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RequirePermission(permissionRepository repositories.IPermissionRepository, permissions ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role, _ := ctx.Locals("role").(string)
+		if role == "" {
+			loggers.ErrorLog.Println("role authentication is empty")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(dto.ResponseJson{
+				Error: "insufficient permission",
+			})
+		}
+
+		allowed, errResponse := permissionRepository.HasPermissionsRepository(role, permissions)
+		if errResponse != nil {
+			loggers.ErrorLog.Println(errResponse.Error)
+			return ctx.Status(errResponse.Status).JSON(dto.ResponseJson{
+				Error: errResponse.Error,
+			})
+		}
+
+		if !allowed {
+			loggers.WarnLog.Println("insufficient permission")
+			return ctx.Status(fiber.StatusForbidden).JSON(dto.ResponseJson{
+				Error: "insufficient permission",
+			})
+		}
+
+		return ctx.Next()
+	}
+}
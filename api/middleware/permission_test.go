@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"shopping-site/api/repositories"
+	"shopping-site/pkg/loggers"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type stubPermissionRepository struct {
+	repositories.IPermissionRepository
+	granted     map[string][]string
+	errResponse *dto.ErrorResponse
+}
+
+func (repo stubPermissionRepository) HasPermissionsRepository(role string, permissions []string) (bool, *dto.ErrorResponse) {
+	if repo.errResponse != nil {
+		return false, repo.errResponse
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(repo.granted[role], permission) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func TestRequirePermission(t *testing.T) {
+	discard := log.New(io.Discard, "", 0)
+	loggers.WarnLog, loggers.ErrorLog = discard, discard
+
+	granted := map[string][]string{
+		constants.UserRole:     {constants.CartManage, constants.OrderPlace},
+		constants.MerchantRole: {constants.CartManage},
+	}
+
+	tests := []struct {
+		name        string
+		role        string
+		errResponse *dto.ErrorResponse
+		want        int
+	}{
+		{name: "all permissions granted", role: constants.UserRole, want: fiber.StatusOK},
+		{name: "one permission missing", role: constants.MerchantRole, want: fiber.StatusForbidden},
+		{name: "unknown role", role: constants.AdminRole, want: fiber.StatusForbidden},
+		{name: "no role", want: fiber.StatusUnauthorized},
+		{name: "lookup failure", role: constants.UserRole, errResponse: &dto.ErrorResponse{Status: fiber.StatusInternalServerError, Error: "lookup failed"}, want: fiber.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		repo := stubPermissionRepository{granted: granted, errResponse: test.errResponse}
+
+		app := fiber.New()
+		app.Get("/", func(ctx *fiber.Ctx) error {
+			if test.role != "" {
+				ctx.Locals("role", test.role)
+			}
+			return ctx.Next()
+		}, RequirePermission(repo, constants.CartManage, constants.OrderPlace), func(ctx *fiber.Ctx) error {
+			return ctx.SendStatus(fiber.StatusOK)
+		})
+
+		response, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		if response.StatusCode != test.want {
+			t.Errorf("%s: status = %d, want %d", test.name, response.StatusCode, test.want)
+		}
+	}
+}
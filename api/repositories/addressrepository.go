@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"errors"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAddressRepository interface {
+	GetAddressesRepository(uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse)
+	GetAddressRepository(uuid.UUID, uuid.UUID) (*models.Addresses, *dto.ErrorResponse)
+	AddAddressRepository(*models.Addresses) *dto.ErrorResponse
+	UpdateAddressRepository(*models.Addresses, map[string]interface{}) *dto.ErrorResponse
+	DeleteAddressRepository(uuid.UUID, uuid.UUID) *dto.ErrorResponse
+}
+
+type addressRepository struct {
+	*gorm.DB
+}
+
+func CommenceAddressRepository(db *gorm.DB) IAddressRepository {
+	return &addressRepository{db}
+}
+
+func clearDefaultAddresses(tx *gorm.DB, address *models.Addresses) error {
+	if address.IsDefaultShipping {
+		err := tx.Model(&models.Addresses{}).Where("user_id = ? AND address_id <> ? AND is_default_shipping", address.UserId, address.AddressId).Update("is_default_shipping", false).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if address.IsDefaultBilling {
+		return tx.Model(&models.Addresses{}).Where("user_id = ? AND address_id <> ? AND is_default_billing", address.UserId, address.AddressId).Update("is_default_billing", false).Error
+	}
+
+	return nil
+}
+
+func (db *addressRepository) GetAddressesRepository(userId uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse) {
+	var addresses []models.Addresses
+
+	record := db.Where("user_id = ?", userId).Order("is_default_shipping DESC, created_at").Find(&addresses)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &addresses, nil
+}
+
+func (db *addressRepository) GetAddressRepository(userId uuid.UUID, addressId uuid.UUID) (*models.Addresses, *dto.ErrorResponse) {
+	var address models.Addresses
+
+	record := db.Where("address_id = ? AND user_id = ?", addressId, userId).First(&address)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "address not found on your profile"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &address, nil
+}
+
+func (db *addressRepository) AddAddressRepository(address *models.Addresses) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		if err := tx.Model(&models.Addresses{}).Where("user_id = ?", address.UserId).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count == 0 {
+			address.IsDefaultShipping = true
+			address.IsDefaultBilling = true
+		}
+
+		if err := tx.Create(address).Error; err != nil {
+			return err
+		}
+
+		return clearDefaultAddresses(tx, address)
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *addressRepository) UpdateAddressRepository(address *models.Addresses, updates map[string]interface{}) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var current models.Addresses
+
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("address_id = ? AND user_id = ?", address.AddressId, address.UserId).First(&current)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		shipping, shippingSent := updates["is_default_shipping"]
+		billing, billingSent := updates["is_default_billing"]
+		if (shippingSent && !shipping.(bool) && current.IsDefaultShipping) || (billingSent && !billing.(bool) && current.IsDefaultBilling) {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict,
+				Error: "default address cannot be unset, mark another address as default instead"}
+			return errors.New(errResponse.Error)
+		}
+
+		record = tx.Model(&models.Addresses{}).Where("address_id = ? AND user_id = ?", address.AddressId, address.UserId).Updates(updates)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return clearDefaultAddresses(tx, address)
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "address not found on your profile"}
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func promoteDefaultAddress(tx *gorm.DB, userId uuid.UUID, column string) error {
+	var count int64
+
+	if err := tx.Model(&models.Addresses{}).Where("user_id = ? AND "+column, userId).Count(&count).Error; err != nil || count > 0 {
+		return err
+	}
+
+	latest := tx.Model(&models.Addresses{}).Select("address_id").Where("user_id = ?", userId).Order("created_at DESC, address_id DESC").Limit(1)
+	return tx.Model(&models.Addresses{}).Where("address_id IN (?)", latest).Update(column, true).Error
+}
+
+func (db *addressRepository) DeleteAddressRepository(userId uuid.UUID, addressId uuid.UUID) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var (
+			address models.Addresses
+			orders  int64
+		)
+
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("address_id = ? AND user_id = ?", addressId, userId).First(&address)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		record = tx.Model(&models.Orders{}).Where("address_id = ?", addressId).Count(&orders)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if orders == 0 {
+			record = tx.Unscoped().Where("address_id = ? AND user_id = ?", addressId, userId).Delete(&models.Addresses{})
+		} else {
+			err := tx.Model(&models.Addresses{}).Where("address_id = ? AND user_id = ?", addressId, userId).Updates(map[string]interface{}{
+				"is_default_shipping": false,
+				"is_default_billing":  false,
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			record = tx.Where("address_id = ? AND user_id = ?", addressId, userId).Delete(&models.Addresses{})
+		}
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if address.IsDefaultShipping {
+			if err := promoteDefaultAddress(tx, userId, "is_default_shipping"); err != nil {
+				return err
+			}
+		}
+
+		if address.IsDefaultBilling {
+			return promoteDefaultAddress(tx, userId, "is_default_billing")
+		}
+
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "address not found on your profile"}
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
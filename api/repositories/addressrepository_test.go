@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestDeleteAddressRepositoryPromotesDefault(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommenceAddressRepository(db)
+
+	user := createTestUser(t, db, constants.UserRole)
+	defaultAddress := user.Address[0]
+
+	var added []models.Addresses
+	for _, street := range []string{"Second Street", "Third Street"} {
+		address := models.Addresses{UserId: user.UserId, DoorNo: "2", Street: street, City: "Chennai", State: "Tamil Nadu", ZipCode: 600002}
+		if errResponse := repo.AddAddressRepository(&address); errResponse != nil {
+			t.Fatalf("add address: %s", errResponse.Error)
+		}
+		added = append(added, address)
+	}
+
+	if errResponse := repo.DeleteAddressRepository(user.UserId, defaultAddress.AddressId); errResponse != nil {
+		t.Fatalf("delete address: %s", errResponse.Error)
+	}
+
+	if _, errResponse := repo.GetAddressRepository(user.UserId, defaultAddress.AddressId); errResponse == nil {
+		t.Fatal("deleted address is still on the profile")
+	}
+
+	promoted, errResponse := repo.GetAddressRepository(user.UserId, added[len(added)-1].AddressId)
+	if errResponse != nil {
+		t.Fatalf("get address: %s", errResponse.Error)
+	}
+
+	if !promoted.IsDefaultShipping || !promoted.IsDefaultBilling {
+		t.Fatalf("most recent address was not promoted: shipping=%v billing=%v", promoted.IsDefaultShipping, promoted.IsDefaultBilling)
+	}
+
+	other, errResponse := repo.GetAddressRepository(user.UserId, added[0].AddressId)
+	if errResponse != nil {
+		t.Fatalf("get address: %s", errResponse.Error)
+	}
+
+	if other.IsDefaultShipping || other.IsDefaultBilling {
+		t.Fatal("older address was promoted alongside the most recent one")
+	}
+}
+
+func TestUpdateAddressRepositoryKeepsDefaultAddress(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommenceAddressRepository(db)
+
+	user := createTestUser(t, db, constants.UserRole)
+	address := user.Address[0]
+
+	address.City = "Madurai"
+	if errResponse := repo.UpdateAddressRepository(&address, map[string]interface{}{"city": address.City}); errResponse != nil {
+		t.Fatalf("update city: %s", errResponse.Error)
+	}
+
+	updated, errResponse := repo.GetAddressRepository(user.UserId, address.AddressId)
+	if errResponse != nil {
+		t.Fatalf("get address: %s", errResponse.Error)
+	}
+
+	if updated.City != "Madurai" || !updated.IsDefaultShipping || !updated.IsDefaultBilling {
+		t.Fatalf("partial update = city %q shipping=%v billing=%v, want Madurai with both defaults kept", updated.City, updated.IsDefaultShipping, updated.IsDefaultBilling)
+	}
+
+	updated.IsDefaultShipping = false
+	errResponse = repo.UpdateAddressRepository(updated, map[string]interface{}{"is_default_shipping": false})
+	if errResponse == nil || errResponse.Status != fiber.StatusConflict {
+		t.Fatalf("unsetting the only default shipping address = %v, want a conflict", errResponse)
+	}
+}
@@ -1,16 +1,33 @@
 package repositories
 
 import (
+	"errors"
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IAdminRepository interface {
-	AddCategoreyRepository(*models.Categories) *dto.ErrorResponse
-	AddBrandRepository(*models.Brands) *dto.ErrorResponse
+	UpdateOrderStatusRepository(uuid.UUID, uuid.UUID, string) *dto.ErrorResponse
+	GetPendingProductsRepository() (*[]models.Products, *dto.ErrorResponse)
+	GetProductsRepository(dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
+	ReviewProductRepository(uuid.UUID, string, string) *dto.ErrorResponse
+	GetTwoFactorPoliciesRepository() (*[]models.TwoFactorPolicies, *dto.ErrorResponse)
+	UpdateTwoFactorPolicyRepository(*models.TwoFactorPolicies) *dto.ErrorResponse
+	UnlockAccountRepository(uuid.UUID) *dto.ErrorResponse
+	GetUsersRepository(dto.UserFilter) (*[]models.Users, int64, *dto.ErrorResponse)
+	GetUserRepository(uuid.UUID) (*models.Users, *dto.ErrorResponse)
+	GetUserOrdersRepository(uuid.UUID) (*[]models.Orders, *dto.ErrorResponse)
+	GetUserAddressesRepository(uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse)
+	UpdateUserAccountRepository(uuid.UUID, map[string]interface{}) *dto.ErrorResponse
+	DeleteUserRepository(uuid.UUID) *dto.ErrorResponse
 }
 
 type adminRepository struct {
@@ -21,14 +38,60 @@ func CommenceAdminRepository(db *gorm.DB) IAdminRepository {
 	return &adminRepository{db}
 }
 
-func (db *adminRepository) AddCategoreyRepository(category *models.Categories) *dto.ErrorResponse {
-	record := db.Where("category_name = ?", category.CategoryName).First(category)
-	if record.RowsAffected > 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusConflict,
-			Error: "category already exists"}
+func (db *adminRepository) UpdateOrderStatusRepository(orderId uuid.UUID, userId uuid.UUID, orderStatus string) *dto.ErrorResponse {
+	return updateOrderStatus(db.DB, orderId, orderStatus, userId, constants.AdminRole)
+}
+
+func (db *adminRepository) GetPendingProductsRepository() (*[]models.Products, *dto.ErrorResponse) {
+	var products []models.Products
+
+	record := db.Where("approval_status = ?", constants.Pending).Find(&products)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &products, nil
+}
+
+func (db *adminRepository) GetProductsRepository(filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	return listProducts(db.DB, filter)
+}
+
+func (db *adminRepository) ReviewProductRepository(productId uuid.UUID, approvalStatus string, reason string) *dto.ErrorResponse {
+	record := db.Model(&models.Products{}).Where("product_id = ?", productId).Updates(map[string]interface{}{
+		"is_approved":      approvalStatus == constants.Approved,
+		"approval_status":  approvalStatus,
+		"rejection_reason": reason,
+	})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product does not exists"}
+	}
+
+	return nil
+}
+
+func (db *adminRepository) GetTwoFactorPoliciesRepository() (*[]models.TwoFactorPolicies, *dto.ErrorResponse) {
+	var policies []models.TwoFactorPolicies
+
+	record := db.Order("role").Find(&policies)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
 	}
 
-	record = db.Create(category)
+	return &policies, nil
+}
+
+func (db *adminRepository) UpdateTwoFactorPolicyRepository(policy *models.TwoFactorPolicies) *dto.ErrorResponse {
+	record := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role"}},
+		DoUpdates: clause.AssignmentColumns([]string{"required", "updated_at"}),
+	}).Create(policy)
 	if record.Error != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
@@ -37,14 +100,19 @@ func (db *adminRepository) AddCategoreyRepository(category *models.Categories) *
 	return nil
 }
 
-func (db *adminRepository) AddBrandRepository(brand *models.Brands) *dto.ErrorResponse {
-	record := db.Where("category_name = ?", brand.BrandName).First(brand)
-	if record.RowsAffected > 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusConflict,
-			Error: "brand already exists"}
+func (db *adminRepository) UnlockAccountRepository(userId uuid.UUID) *dto.ErrorResponse {
+	var user models.Users
+
+	record := db.Where("user_id = ?", userId).Limit(1).Find(&user)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "user does not exists"}
 	}
 
-	record = db.Create(brand)
+	record = db.Where("attempt_key = ?", constants.AccountAttemptPrefix+strings.ToLower(strings.TrimSpace(user.Email))).Delete(&models.LoginAttempts{})
 	if record.Error != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
@@ -52,3 +120,126 @@ func (db *adminRepository) AddBrandRepository(brand *models.Brands) *dto.ErrorRe
 
 	return nil
 }
+
+func (db *adminRepository) GetUsersRepository(filter dto.UserFilter) (*[]models.Users, int64, *dto.ErrorResponse) {
+	var (
+		users []models.Users
+		total int64
+	)
+
+	query := db.Model(&models.Users{})
+	switch filter.Status {
+	case constants.ActiveStatus:
+		query = query.Where("suspended_at IS NULL")
+	case constants.SuspendedStatus:
+		query = query.Where("suspended_at IS NOT NULL")
+	case constants.DeletedStatus:
+		query = query.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+
+	if filter.Search != "" {
+		search := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Search) + "%"
+		query = query.Where("first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR phone LIKE ?", search, search, search, search)
+	}
+
+	record := query.Count(&total)
+	if record.Error != nil {
+		return nil, 0, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	record = query.Order("created_at DESC").Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit).Find(&users)
+	if record.Error != nil {
+		return nil, 0, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &users, total, nil
+}
+
+func (db *adminRepository) GetUserRepository(userId uuid.UUID) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
+
+	record := db.Unscoped().Where("user_id = ?", userId).Limit(1).Find(&user)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "user does not exists"}
+	}
+
+	return &user, nil
+}
+
+func (db *adminRepository) GetUserOrdersRepository(userId uuid.UUID) (*[]models.Orders, *dto.ErrorResponse) {
+	var orders []models.Orders
+
+	record := db.Preload("Products").Preload("Shipments").Where("user_id = ?", userId).Order("created_at DESC").Find(&orders)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &orders, nil
+}
+
+func (db *adminRepository) GetUserAddressesRepository(userId uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse) {
+	var addresses []models.Addresses
+
+	record := db.Where("user_id = ?", userId).Find(&addresses)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &addresses, nil
+}
+
+func (db *adminRepository) UpdateUserAccountRepository(userId uuid.UUID, updates map[string]interface{}) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&models.Users{}).Where("user_id = ?", userId).Updates(updates)
+		if record.Error != nil {
+			return record.Error
+		} else if record.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", userId).Update("revoked_at", time.Now()).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "user does not exists"}
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *adminRepository) DeleteUserRepository(userId uuid.UUID) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Where("user_id = ?", userId).Delete(&models.Users{})
+		if record.Error != nil {
+			return record.Error
+		} else if record.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", userId).Update("revoked_at", time.Now()).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "user does not exists"}
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
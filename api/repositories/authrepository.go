@@ -4,14 +4,40 @@ import (
 	"errors"
 	"net/http"
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IAuthRepository interface {
 	LoginUser(dto.LoginRequest) (*models.Users, *dto.ErrorResponse)
-	SignUpUser(models.Users) *dto.ErrorResponse
+	SignUpUser(*models.Users) *dto.ErrorResponse
+	GetUserByEmailRepository(string) (*models.Users, *dto.ErrorResponse)
+	VerifyEmailRepository(uuid.UUID, string) *dto.ErrorResponse
+	CreateSessionRepository(uuid.UUID, string, time.Time, bool) (*models.Sessions, *dto.ErrorResponse)
+	RotateRefreshTokenRepository(string, string, time.Time) (*models.Users, *models.Sessions, *dto.ErrorResponse)
+	ValidateSessionRepository(uuid.UUID) (*models.Sessions, *dto.ErrorResponse)
+	RevokeSessionRepository(uuid.UUID) *dto.ErrorResponse
+	RevokeUserSessionsRepository(uuid.UUID) *dto.ErrorResponse
+	CreatePasswordResetRepository(uuid.UUID, string, time.Time) *dto.ErrorResponse
+	ResetPasswordRepository(string, string) *dto.ErrorResponse
+	ForcePasswordResetRepository(uuid.UUID) (*models.Users, *dto.ErrorResponse)
+	GetUserByIdRepository(uuid.UUID) (*models.Users, *dto.ErrorResponse)
+	SetTwoFactorSecretRepository(uuid.UUID, string) *dto.ErrorResponse
+	EnableTwoFactorRepository(uuid.UUID, uuid.UUID, int64, []string) *dto.ErrorResponse
+	DisableTwoFactorRepository(uuid.UUID) *dto.ErrorResponse
+	ConsumeTwoFactorStepRepository(uuid.UUID, int64) *dto.ErrorResponse
+	CreateTwoFactorChallengeRepository(uuid.UUID, time.Time) (uuid.UUID, *dto.ErrorResponse)
+	ConsumeTwoFactorChallengeRepository(uuid.UUID, uuid.UUID) *dto.ErrorResponse
+	UseRecoveryCodeRepository(uuid.UUID, string) *dto.ErrorResponse
+	IsTwoFactorRequiredRepository(string) (bool, *dto.ErrorResponse)
+	GetLoginLockRepository(...string) (*time.Time, *dto.ErrorResponse)
+	RecordLoginFailureRepository(string, uint) *dto.ErrorResponse
+	ClearLoginFailuresRepository(string) *dto.ErrorResponse
 }
 
 type authRepository struct {
@@ -22,10 +48,12 @@ func CommenceAuthRepository(db *gorm.DB) IAuthRepository {
 	return &authRepository{db}
 }
 
-func (db *authRepository) SignUpUser(user models.Users) *dto.ErrorResponse {
-	record := db.Where("email=?", user.Email).First(&user)
+func (db *authRepository) SignUpUser(user *models.Users) *dto.ErrorResponse {
+	var userExcist models.Users
+
+	record := db.Where("email=?", user.Email).First(&userExcist)
 	if record.RowsAffected == 0 {
-		record = db.Create(&user)
+		record = db.Create(user)
 		if record.Error != nil {
 			return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
 		}
@@ -50,3 +78,422 @@ func (db *authRepository) LoginUser(loginRequest dto.LoginRequest) (*models.User
 
 	return &user, nil
 }
+
+func (db *authRepository) CreateSessionRepository(userId uuid.UUID, tokenHash string, expiresAt time.Time, twoFactorVerified bool) (*models.Sessions, *dto.ErrorResponse) {
+	session := models.Sessions{UserId: userId, TwoFactorVerified: twoFactorVerified}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.RefreshTokens{SessionId: session.SessionId, TokenHash: tokenHash, ExpiresAt: expiresAt}).Error
+	})
+	if err != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return &session, nil
+}
+
+func (db *authRepository) RotateRefreshTokenRepository(tokenHash string, newTokenHash string, expiresAt time.Time) (*models.Users, *models.Sessions, *dto.ErrorResponse) {
+	var (
+		user         models.Users
+		refreshToken models.RefreshTokens
+		errResponse  *dto.ErrorResponse
+	)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Session").Where("token_hash = ?", tokenHash).First(&refreshToken)
+		if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+			errResponse = &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid refresh token"}
+			return nil
+		} else if record.Error != nil {
+			return record.Error
+		}
+
+		if refreshToken.Session.RevokedAt != nil {
+			errResponse = &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "session revoked, please login again"}
+			return nil
+		}
+
+		if refreshToken.UsedAt != nil {
+			errResponse = &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "refresh token reuse detected, please login again"}
+			return tx.Model(&models.Sessions{}).Where("session_id = ?", refreshToken.SessionId).Update("revoked_at", time.Now()).Error
+		}
+
+		if time.Now().After(refreshToken.ExpiresAt) {
+			errResponse = &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "session expired, please login again"}
+			return nil
+		}
+
+		record = tx.Model(&refreshToken).Where("refresh_token_id = ?", refreshToken.RefreshTokenId).Update("used_at", time.Now())
+		if record.Error != nil {
+			return record.Error
+		}
+
+		record = tx.Create(&models.RefreshTokens{SessionId: refreshToken.SessionId, TokenHash: newTokenHash, ExpiresAt: expiresAt})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Where("user_id = ?", refreshToken.Session.UserId).First(&user).Error
+	})
+	if err != nil {
+		return nil, nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	} else if errResponse != nil {
+		return nil, nil, errResponse
+	}
+
+	return &user, &refreshToken.Session, nil
+}
+
+func (db *authRepository) ValidateSessionRepository(sessionId uuid.UUID) (*models.Sessions, *dto.ErrorResponse) {
+	var session models.Sessions
+
+	record := db.Where("session_id = ?", sessionId).First(&session)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid session"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	if session.RevokedAt != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "session revoked, please login again"}
+	}
+
+	return &session, nil
+}
+
+func (db *authRepository) RevokeSessionRepository(sessionId uuid.UUID) *dto.ErrorResponse {
+	record := db.Model(&models.Sessions{}).Where("session_id = ? AND revoked_at IS NULL", sessionId).Update("revoked_at", time.Now())
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) RevokeUserSessionsRepository(userId uuid.UUID) *dto.ErrorResponse {
+	record := db.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", userId).Update("revoked_at", time.Now())
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) GetUserByEmailRepository(email string) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
+
+	record := db.Where("email = ?", email).First(&user)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: http.StatusNotFound, Error: "user not found"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return &user, nil
+}
+
+func (db *authRepository) VerifyEmailRepository(userId uuid.UUID, email string) *dto.ErrorResponse {
+	record := db.Model(&models.Users{}).Where("user_id = ? AND email = ?", userId, email).Update("is_verified", true)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "invalid verification token"}
+	}
+
+	return nil
+}
+
+func (db *authRepository) CreatePasswordResetRepository(userId uuid.UUID, tokenHash string, expiresAt time.Time) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&models.PasswordResetTokens{}).Where("user_id = ? AND used_at IS NULL", userId).Update("used_at", time.Now())
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Create(&models.PasswordResetTokens{UserId: userId, TokenHash: tokenHash, ExpiresAt: expiresAt}).Error
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) ResetPasswordRepository(tokenHash string, hashedPassword string) *dto.ErrorResponse {
+	var (
+		resetToken  models.PasswordResetTokens
+		errResponse *dto.ErrorResponse
+	)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).First(&resetToken)
+		if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+			errResponse = &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "invalid or expired reset token"}
+			return errors.New(errResponse.Error)
+		} else if record.Error != nil {
+			return record.Error
+		}
+
+		if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+			errResponse = &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "invalid or expired reset token"}
+			return errors.New(errResponse.Error)
+		}
+
+		record = tx.Model(&resetToken).Where("password_reset_token_id = ?", resetToken.PasswordResetTokenId).Update("used_at", time.Now())
+		if record.Error != nil {
+			return record.Error
+		}
+
+		record = tx.Model(&models.Users{}).Where("user_id = ?", resetToken.UserId).Updates(map[string]interface{}{
+			"password":            hashedPassword,
+			"must_reset_password": false,
+		})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", resetToken.UserId).Update("revoked_at", time.Now()).Error
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) ForcePasswordResetRepository(userId uuid.UUID) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&user).Clauses(clause.Returning{}).Where("user_id = ?", userId).Update("must_reset_password", true)
+		if record.Error != nil {
+			return record.Error
+		} else if record.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", userId).Update("revoked_at", time.Now()).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: http.StatusNotFound, Error: "user not found"}
+	} else if err != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return &user, nil
+}
+
+func (db *authRepository) GetUserByIdRepository(userId uuid.UUID) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
+
+	record := db.Where("user_id = ?", userId).First(&user)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: http.StatusNotFound, Error: "user not found"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return &user, nil
+}
+
+func (db *authRepository) SetTwoFactorSecretRepository(userId uuid.UUID, secret string) *dto.ErrorResponse {
+	record := db.Model(&models.Users{}).Where("user_id = ? AND two_factor_enabled = ?", userId, false).Update("two_factor_secret", secret)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: http.StatusConflict, Error: "two-factor authentication already enabled"}
+	}
+
+	return nil
+}
+
+func (db *authRepository) EnableTwoFactorRepository(userId uuid.UUID, sessionId uuid.UUID, step int64, codeHashes []string) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&models.Users{}).Where("user_id = ?", userId).Updates(map[string]interface{}{
+			"two_factor_enabled":   true,
+			"two_factor_last_step": step,
+		})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		record = tx.Where("user_id = ?", userId).Delete(&models.RecoveryCodes{})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		recoveryCodes := make([]models.RecoveryCodes, 0, len(codeHashes))
+		for _, codeHash := range codeHashes {
+			recoveryCodes = append(recoveryCodes, models.RecoveryCodes{UserId: userId, CodeHash: codeHash})
+		}
+
+		record = tx.Create(&recoveryCodes)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Model(&models.Sessions{}).Where("session_id = ?", sessionId).Update("two_factor_verified", true).Error
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) DisableTwoFactorRepository(userId uuid.UUID) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&models.Users{}).Where("user_id = ?", userId).Updates(map[string]interface{}{
+			"two_factor_enabled": false,
+			"two_factor_secret":  "",
+		})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Where("user_id = ?", userId).Delete(&models.RecoveryCodes{}).Error
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) ConsumeTwoFactorStepRepository(userId uuid.UUID, step int64) *dto.ErrorResponse {
+	record := db.Model(&models.Users{}).Where("user_id = ? AND two_factor_last_step < ?", userId, step).Update("two_factor_last_step", step)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid two-factor code"}
+	}
+
+	return nil
+}
+
+func (db *authRepository) CreateTwoFactorChallengeRepository(userId uuid.UUID, expiresAt time.Time) (uuid.UUID, *dto.ErrorResponse) {
+	challenge := models.TwoFactorChallenges{UserId: userId, ExpiresAt: expiresAt}
+
+	record := db.Create(&challenge)
+	if record.Error != nil {
+		return uuid.Nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return challenge.ChallengeId, nil
+}
+
+func (db *authRepository) ConsumeTwoFactorChallengeRepository(challengeId uuid.UUID, userId uuid.UUID) *dto.ErrorResponse {
+	now := time.Now()
+
+	record := db.Model(&models.TwoFactorChallenges{}).Where("challenge_id = ? AND user_id = ? AND used_at IS NULL AND expires_at > ?", challengeId, userId, now).Update("used_at", now)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid or expired challenge token"}
+	}
+
+	return nil
+}
+
+func (db *authRepository) UseRecoveryCodeRepository(userId uuid.UUID, codeHash string) *dto.ErrorResponse {
+	record := db.Model(&models.RecoveryCodes{}).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).Update("used_at", time.Now())
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid recovery code"}
+	}
+
+	return nil
+}
+
+func (db *authRepository) IsTwoFactorRequiredRepository(role string) (bool, *dto.ErrorResponse) {
+	var policy models.TwoFactorPolicies
+
+	record := db.Where("role = ?", role).Limit(1).Find(&policy)
+	if record.Error != nil {
+		return false, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return policy.Required, nil
+}
+
+func loginLockDuration(failures uint, threshold uint) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+
+	lockDuration := constants.LoginBackoffBase
+	for range failures - threshold {
+		lockDuration *= 2
+		if lockDuration >= constants.LoginLockoutMax {
+			return constants.LoginLockoutMax
+		}
+	}
+
+	return lockDuration
+}
+
+func (db *authRepository) GetLoginLockRepository(attemptKeys ...string) (*time.Time, *dto.ErrorResponse) {
+	var attempts []models.LoginAttempts
+
+	record := db.Where("attempt_key IN ? AND locked_until > ?", attemptKeys, time.Now()).Order("locked_until DESC").Find(&attempts)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+
+	return attempts[0].LockedUntil, nil
+}
+
+func (db *authRepository) RecordLoginFailureRepository(attemptKey string, threshold uint) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var attempt models.LoginAttempts
+		now := time.Now()
+
+		record := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.LoginAttempts{AttemptKey: attemptKey, LastFailedAt: now})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		record = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("attempt_key = ?", attemptKey).First(&attempt)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if now.Sub(attempt.LastFailedAt) > constants.LoginAttemptWindow {
+			attempt.Failures = 0
+		}
+
+		attempt.Failures++
+		attempt.LastFailedAt = now
+		if lockDuration := loginLockDuration(attempt.Failures, threshold); lockDuration > 0 {
+			lockedUntil := now.Add(lockDuration)
+			attempt.LockedUntil = &lockedUntil
+		}
+
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *authRepository) ClearLoginFailuresRepository(attemptKey string) *dto.ErrorResponse {
+	record := db.Where("attempt_key = ?", attemptKey).Delete(&models.LoginAttempts{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: record.Error.Error()}
+	}
+
+	return nil
+}
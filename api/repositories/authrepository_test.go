@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"testing"
+	"time"
+)
+
+func TestLoginLockDuration(t *testing.T) {
+	tests := []struct {
+		failures uint
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 4, want: 0},
+		{failures: 5, want: constants.LoginBackoffBase},
+		{failures: 6, want: 2 * constants.LoginBackoffBase},
+		{failures: 7, want: 4 * constants.LoginBackoffBase},
+		{failures: 10, want: 32 * constants.LoginBackoffBase},
+		{failures: 11, want: constants.LoginLockoutMax},
+		{failures: 100, want: constants.LoginLockoutMax},
+	}
+
+	for _, test := range tests {
+		if got := loginLockDuration(test.failures, 5); got != test.want {
+			t.Errorf("loginLockDuration(%d, 5) = %v, want %v", test.failures, got, test.want)
+		}
+	}
+}
+
+func getLoginAttempt(t *testing.T, repo *authRepository, attemptKey string) models.LoginAttempts {
+	t.Helper()
+
+	var attempt models.LoginAttempts
+	if err := repo.Where("attempt_key = ?", attemptKey).First(&attempt).Error; err != nil {
+		t.Fatalf("load login attempt: %v", err)
+	}
+
+	return attempt
+}
+
+func TestRecordLoginFailureRepositoryLocksAtThreshold(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := &authRepository{db}
+	attemptKey := constants.AccountAttemptPrefix + uniqueSuffix()
+	t.Cleanup(func() { repo.ClearLoginFailuresRepository(attemptKey) })
+
+	for range constants.AccountAttemptThreshold - 1 {
+		if errResponse := repo.RecordLoginFailureRepository(attemptKey, constants.AccountAttemptThreshold); errResponse != nil {
+			t.Fatalf("record failure: %s", errResponse.Error)
+		}
+	}
+
+	if attempt := getLoginAttempt(t, repo, attemptKey); attempt.LockedUntil != nil {
+		t.Fatalf("locked after %d failures, want unlocked below threshold", attempt.Failures)
+	}
+
+	lockedUntil, errResponse := repo.GetLoginLockRepository(attemptKey)
+	if errResponse != nil {
+		t.Fatalf("get lock: %s", errResponse.Error)
+	} else if lockedUntil != nil {
+		t.Fatalf("lock reported below threshold: %v", lockedUntil)
+	}
+
+	before := time.Now().Truncate(time.Microsecond)
+	if errResponse := repo.RecordLoginFailureRepository(attemptKey, constants.AccountAttemptThreshold); errResponse != nil {
+		t.Fatalf("record failure: %s", errResponse.Error)
+	}
+
+	attempt := getLoginAttempt(t, repo, attemptKey)
+	if attempt.Failures != constants.AccountAttemptThreshold {
+		t.Fatalf("failures = %d, want %d", attempt.Failures, constants.AccountAttemptThreshold)
+	}
+	if attempt.LockedUntil == nil || attempt.LockedUntil.Before(before.Add(constants.LoginBackoffBase)) {
+		t.Fatalf("locked until %v, want at least %v", attempt.LockedUntil, before.Add(constants.LoginBackoffBase))
+	}
+
+	lockedUntil, errResponse = repo.GetLoginLockRepository(constants.IPAttemptPrefix+uniqueSuffix(), attemptKey)
+	if errResponse != nil {
+		t.Fatalf("get lock: %s", errResponse.Error)
+	} else if lockedUntil == nil || !lockedUntil.Equal(*attempt.LockedUntil) {
+		t.Fatalf("lock = %v, want %v", lockedUntil, attempt.LockedUntil)
+	}
+
+	if errResponse := repo.RecordLoginFailureRepository(attemptKey, constants.AccountAttemptThreshold); errResponse != nil {
+		t.Fatalf("record failure: %s", errResponse.Error)
+	}
+
+	next := getLoginAttempt(t, repo, attemptKey)
+	if next.LockedUntil.Sub(next.LastFailedAt) != 2*constants.LoginBackoffBase {
+		t.Fatalf("lock duration = %v, want %v", next.LockedUntil.Sub(next.LastFailedAt), 2*constants.LoginBackoffBase)
+	}
+
+	if errResponse := repo.ClearLoginFailuresRepository(attemptKey); errResponse != nil {
+		t.Fatalf("clear failures: %s", errResponse.Error)
+	}
+
+	lockedUntil, errResponse = repo.GetLoginLockRepository(attemptKey)
+	if errResponse != nil {
+		t.Fatalf("get lock: %s", errResponse.Error)
+	} else if lockedUntil != nil {
+		t.Fatalf("lock reported after clear: %v", lockedUntil)
+	}
+}
+
+func TestRecordLoginFailureRepositoryResetsAfterWindow(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := &authRepository{db}
+	attemptKey := constants.AccountAttemptPrefix + uniqueSuffix()
+	t.Cleanup(func() { repo.ClearLoginFailuresRepository(attemptKey) })
+
+	lastFailedAt := time.Now().Add(-constants.LoginAttemptWindow - time.Minute)
+	lockedUntil := lastFailedAt.Add(constants.LoginBackoffBase)
+	stale := models.LoginAttempts{
+		AttemptKey:   attemptKey,
+		Failures:     constants.AccountAttemptThreshold,
+		LockedUntil:  &lockedUntil,
+		LastFailedAt: lastFailedAt,
+	}
+	if err := db.Create(&stale).Error; err != nil {
+		t.Fatalf("create login attempt: %v", err)
+	}
+
+	if errResponse := repo.RecordLoginFailureRepository(attemptKey, constants.AccountAttemptThreshold); errResponse != nil {
+		t.Fatalf("record failure: %s", errResponse.Error)
+	}
+
+	attempt := getLoginAttempt(t, repo, attemptKey)
+	if attempt.Failures != 1 {
+		t.Fatalf("failures = %d, want 1 after the window expired", attempt.Failures)
+	}
+
+	current, errResponse := repo.GetLoginLockRepository(attemptKey)
+	if errResponse != nil {
+		t.Fatalf("get lock: %s", errResponse.Error)
+	} else if current != nil {
+		t.Fatalf("lock reported after the window expired: %v", current)
+	}
+}
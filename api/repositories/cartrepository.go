@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ICartRepository interface {
+	GetCartRepository(uuid.UUID) (*models.Carts, *dto.ErrorResponse)
+	AddCartItemRepository(uuid.UUID, dto.CartItemRequest) *dto.ErrorResponse
+	UpdateCartItemRepository(uuid.UUID, dto.CartItemRequest) *dto.ErrorResponse
+	RemoveCartItemRepository(uuid.UUID, uuid.UUID) *dto.ErrorResponse
+	ClearCartRepository(uuid.UUID) *dto.ErrorResponse
+}
+
+type cartRepository struct {
+	*gorm.DB
+}
+
+func CommenceCartRepository(db *gorm.DB) ICartRepository {
+	return &cartRepository{db}
+}
+
+func (db *cartRepository) findOrCreateCart(userId uuid.UUID) (*models.Carts, *dto.ErrorResponse) {
+	var cart models.Carts
+
+	record := db.Where(models.Carts{UserId: userId}).FirstOrCreate(&cart)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &cart, nil
+}
+
+func (db *cartRepository) GetCartRepository(userId uuid.UUID) (*models.Carts, *dto.ErrorResponse) {
+	cart, errResponse := db.findOrCreateCart(userId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	record := db.Preload("Items.Product").Where("cart_id = ?", cart.CartId).First(cart)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return cart, nil
+}
+
+func (db *cartRepository) AddCartItemRepository(userId uuid.UUID, item dto.CartItemRequest) *dto.ErrorResponse {
+	var product models.Products
+
+	record := db.Where("product_id = ? AND is_approved = ?", item.ProductId, true).First(&product)
+	if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product does not exists"}
+	}
+
+	cart, errResponse := db.findOrCreateCart(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	record = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cart_id"}, {Name: "product_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("cart_items.quantity + excluded.quantity")}),
+	}).Create(&models.CartItems{CartId: cart.CartId, ProductId: item.ProductId, Quantity: item.Quantity})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *cartRepository) UpdateCartItemRepository(userId uuid.UUID, item dto.CartItemRequest) *dto.ErrorResponse {
+	cart, errResponse := db.findOrCreateCart(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Model(&models.CartItems{}).Where("cart_id = ? AND product_id = ?", cart.CartId, item.ProductId).Update("quantity", item.Quantity)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product not found in cart"}
+	}
+
+	return nil
+}
+
+func (db *cartRepository) RemoveCartItemRepository(userId uuid.UUID, productId uuid.UUID) *dto.ErrorResponse {
+	cart, errResponse := db.findOrCreateCart(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Where("cart_id = ? AND product_id = ?", cart.CartId, productId).Delete(&models.CartItems{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product not found in cart"}
+	}
+
+	return nil
+}
+
+func (db *cartRepository) ClearCartRepository(userId uuid.UUID) *dto.ErrorResponse {
+	cart, errResponse := db.findOrCreateCart(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Where("cart_id = ?", cart.CartId).Delete(&models.CartItems{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
@@ -0,0 +1,366 @@
+package repositories
+
+import (
+	"errors"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const categoryDescendantsQuery = `WITH RECURSIVE tree AS (
+		SELECT category_id FROM categories WHERE category_id IN (?) AND deleted_at IS NULL
+		UNION ALL
+		SELECT c.category_id FROM categories AS c INNER JOIN tree AS t ON c.parent_id = t.category_id WHERE c.deleted_at IS NULL
+	) SELECT category_id FROM tree`
+
+type ICatalogRepository interface {
+	AddCategoryRepository(*models.Categories) *dto.ErrorResponse
+	GetCategoriesRepository() (*[]models.Categories, *dto.ErrorResponse)
+	GetCategoryRepository(uuid.UUID) (*models.Categories, *dto.ErrorResponse)
+	GetCategoryBySlugRepository(string) (*models.Categories, *dto.ErrorResponse)
+	GetCategoryDescendantsRepository(uuid.UUID) ([]uuid.UUID, *dto.ErrorResponse)
+	UpdateCategoryRepository(*models.Categories, map[string]interface{}) *dto.ErrorResponse
+	DeleteCategoryRepository(uuid.UUID) *dto.ErrorResponse
+	AddBrandRepository(*models.Brands) *dto.ErrorResponse
+	GetBrandsRepository() (*[]models.Brands, *dto.ErrorResponse)
+	GetBrandRepository(uuid.UUID) (*models.Brands, *dto.ErrorResponse)
+	UpdateBrandRepository(*models.Brands, map[string]interface{}) *dto.ErrorResponse
+	DeleteBrandRepository(uuid.UUID) *dto.ErrorResponse
+}
+
+type catalogRepository struct {
+	*gorm.DB
+}
+
+func CommenceCatalogRepository(db *gorm.DB) ICatalogRepository {
+	return &catalogRepository{db}
+}
+
+func (db *catalogRepository) checkCategoryPlacement(category *models.Categories) *dto.ErrorResponse {
+	var count int64
+
+	query := db.Model(&models.Categories{}).Where("category_id <> ?", category.CategoryId)
+	if category.ParentId == nil {
+		query = query.Where("category_name = ? AND parent_id IS NULL", category.CategoryName)
+	} else {
+		query = query.Where("category_name = ? AND parent_id = ?", category.CategoryName, category.ParentId)
+	}
+
+	record := query.Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "category already exists"}
+	}
+
+	if category.ParentId == nil {
+		return nil
+	}
+
+	record = db.Model(&models.Categories{}).Where("category_id = ?", category.ParentId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "parent category does not exists"}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) checkCategorySlug(category *models.Categories) *dto.ErrorResponse {
+	var count int64
+
+	record := db.Model(&models.Categories{}).Where("slug = ? AND category_id <> ?", category.Slug, category.CategoryId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "category slug already exists"}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) checkCategoryConflict(category *models.Categories) *dto.ErrorResponse {
+	if errResponse := db.checkCategoryPlacement(category); errResponse != nil {
+		return errResponse
+	}
+
+	return db.checkCategorySlug(category)
+}
+
+func (db *catalogRepository) AddCategoryRepository(category *models.Categories) *dto.ErrorResponse {
+	if errResponse := db.checkCategoryConflict(category); errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Create(category)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) GetCategoriesRepository() (*[]models.Categories, *dto.ErrorResponse) {
+	var categories []models.Categories
+
+	record := db.Order("category_name").Find(&categories)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &categories, nil
+}
+
+func (db *catalogRepository) GetCategoryRepository(categoryId uuid.UUID) (*models.Categories, *dto.ErrorResponse) {
+	var category models.Categories
+
+	record := db.Where("category_id = ?", categoryId).First(&category)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "category does not exists"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &category, nil
+}
+
+func (db *catalogRepository) GetCategoryBySlugRepository(slug string) (*models.Categories, *dto.ErrorResponse) {
+	var category models.Categories
+
+	record := db.Where("slug = ?", slug).First(&category)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "category does not exists"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &category, nil
+}
+
+func (db *catalogRepository) GetCategoryDescendantsRepository(categoryId uuid.UUID) ([]uuid.UUID, *dto.ErrorResponse) {
+	var categoryIds []uuid.UUID
+
+	record := db.Raw(categoryDescendantsQuery, categoryId).Scan(&categoryIds)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return categoryIds, nil
+}
+
+func (db *catalogRepository) UpdateCategoryRepository(category *models.Categories, updates map[string]interface{}) *dto.ErrorResponse {
+	_, nameChanged := updates["category_name"]
+	_, parentChanged := updates["parent_id"]
+	_, slugChanged := updates["slug"]
+
+	if parentChanged && category.ParentId != nil {
+		descendants, errResponse := db.GetCategoryDescendantsRepository(category.CategoryId)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		for _, descendant := range descendants {
+			if descendant == *category.ParentId {
+				return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+					Error: "category cannot be moved under itself or its descendants"}
+			}
+		}
+	}
+
+	if nameChanged || parentChanged {
+		if errResponse := db.checkCategoryPlacement(category); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	if slugChanged {
+		if errResponse := db.checkCategorySlug(category); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	record := db.Model(&models.Categories{}).Where("category_id = ?", category.CategoryId).Updates(updates)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) DeleteCategoryRepository(categoryId uuid.UUID) *dto.ErrorResponse {
+	var count int64
+
+	if _, errResponse := db.GetCategoryRepository(categoryId); errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Model(&models.Categories{}).Where("parent_id = ?", categoryId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "category has sub categories"}
+	}
+
+	record = db.Model(&models.Products{}).Where("category_id = ?", categoryId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "category is referenced by products"}
+	}
+
+	record = db.Where("category_id = ?", categoryId).Delete(&models.Categories{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) checkBrandName(brand *models.Brands) *dto.ErrorResponse {
+	var count int64
+
+	record := db.Model(&models.Brands{}).Where("brand_name = ? AND brand_id <> ?", brand.BrandName, brand.BrandId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "brand already exists"}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) checkBrandSlug(brand *models.Brands) *dto.ErrorResponse {
+	var count int64
+
+	record := db.Model(&models.Brands{}).Where("slug = ? AND brand_id <> ?", brand.Slug, brand.BrandId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "brand slug already exists"}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) checkBrandConflict(brand *models.Brands) *dto.ErrorResponse {
+	if errResponse := db.checkBrandName(brand); errResponse != nil {
+		return errResponse
+	}
+
+	return db.checkBrandSlug(brand)
+}
+
+func (db *catalogRepository) AddBrandRepository(brand *models.Brands) *dto.ErrorResponse {
+	if errResponse := db.checkBrandConflict(brand); errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Create(brand)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) GetBrandsRepository() (*[]models.Brands, *dto.ErrorResponse) {
+	var brands []models.Brands
+
+	record := db.Order("brand_name").Find(&brands)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &brands, nil
+}
+
+func (db *catalogRepository) GetBrandRepository(brandId uuid.UUID) (*models.Brands, *dto.ErrorResponse) {
+	var brand models.Brands
+
+	record := db.Where("brand_id = ?", brandId).First(&brand)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "brand does not exists"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &brand, nil
+}
+
+func (db *catalogRepository) UpdateBrandRepository(brand *models.Brands, updates map[string]interface{}) *dto.ErrorResponse {
+	if _, nameChanged := updates["brand_name"]; nameChanged {
+		if errResponse := db.checkBrandName(brand); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	if _, slugChanged := updates["slug"]; slugChanged {
+		if errResponse := db.checkBrandSlug(brand); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	record := db.Model(&models.Brands{}).Where("brand_id = ?", brand.BrandId).Updates(updates)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *catalogRepository) DeleteBrandRepository(brandId uuid.UUID) *dto.ErrorResponse {
+	var count int64
+
+	if _, errResponse := db.GetBrandRepository(brandId); errResponse != nil {
+		return errResponse
+	}
+
+	record := db.Model(&models.Products{}).Where("brand_id = ?", brandId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "brand is referenced by products"}
+	}
+
+	record = db.Where("brand_id = ?", brandId).Delete(&models.Brands{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,11 +14,14 @@ type IMerchantRepository interface {
 	AddProductRepository(*models.Products) *dto.ErrorResponse
 	RemoveProductRepository(uuid.UUID) *dto.ErrorResponse
 	UpdateProductRepository(*models.Products) *dto.ErrorResponse
-	UpdateMerchantRepository(*models.Users) *dto.ErrorResponse
-	UpdateOrderStatusRepository(uuid.UUID, uuid.UUID, string) *dto.ErrorResponse
-	GetProductsRepository(map[string]string, uuid.UUID) (*[]models.Products, *dto.ErrorResponse)
+	UpdateStockRepository(uuid.UUID, uuid.UUID, uint) *dto.ErrorResponse
+	UpdateMerchantRepository(uuid.UUID, map[string]interface{}, []models.Addresses) *dto.ErrorResponse
+	GetMerchantRepository(uuid.UUID) (*models.Users, *dto.ErrorResponse)
+	UpdatePasswordRepository(uuid.UUID, uuid.UUID, string) *dto.ErrorResponse
+	UpdateOrderStatusRepository(uuid.UUID, uuid.UUID, dto.ShipmentUpdate) *dto.ErrorResponse
+	GetProductsRepository(dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
 	GetProductRepository(uuid.UUID, uuid.UUID) (*models.Products, *dto.ErrorResponse)
-	GetOrdersRepository(uuid.UUID) (*models.Orders, *dto.ErrorResponse)
+	GetOrdersRepository(uuid.UUID, dto.OrderFilter) (*[]models.Orders, *dto.ErrorResponse)
 }
 
 type merchantRepository struct {
@@ -29,12 +33,32 @@ func CommenceMerchantRepository(db *gorm.DB) IMerchantRepository {
 }
 
 func (db *merchantRepository) AddProductRepository(product *models.Products) *dto.ErrorResponse {
+	var count int64
+
 	record := db.Where("product_name = ? AND user_id = ?", product.ProductName, product.UserId).First(product)
 	if record.RowsAffected > 0 {
 		return &dto.ErrorResponse{Status: fiber.StatusConflict,
 			Error: "product already exists on your listing"}
 	}
 
+	record = db.Model(&models.Categories{}).Where("category_id = ?", product.CategoryId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "category does not exists"}
+	}
+
+	record = db.Model(&models.Brands{}).Where("brand_id = ?", product.BrandId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "brand does not exists"}
+	}
+
 	record = db.Create(product)
 	if record.Error != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
@@ -74,7 +98,18 @@ func (db *merchantRepository) UpdateProductRepository(product *models.Products)
 			Error: "product not found on your listing"}
 	}
 
-	record = db.Where("product_id = ?", product.ProductId).Updates(models.Products{ProductName: product.ProductName, Price: product.Price})
+	updates := map[string]interface{}{"product_name": product.ProductName, "price": product.Price}
+	if product.Description != "" {
+		updates["description"] = product.Description
+	}
+
+	if product.ProductName != productExcist.ProductName || product.Price != productExcist.Price || (product.Description != "" && product.Description != productExcist.Description) {
+		updates["is_approved"] = false
+		updates["approval_status"] = constants.Pending
+		updates["rejection_reason"] = ""
+	}
+
+	record = db.Model(&models.Products{}).Where("product_id = ?", product.ProductId).Updates(updates)
 	if record.Error != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
@@ -86,82 +121,45 @@ func (db *merchantRepository) UpdateProductRepository(product *models.Products)
 	return nil
 }
 
-func (db *merchantRepository) UpdateOrderStatusRepository(orderId uuid.UUID, userId uuid.UUID, orderStatus string) *dto.ErrorResponse {
-	var orderExcist models.Orders
-
-	record := db.Where("order_id= ? AND user_id", orderId, userId).First(&orderExcist)
-	if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
-			Error: "product not found on your listing"}
-	}
-
-	record = db.Where("order_id = ?", orderId).Updates(models.Orders{Status: orderStatus})
+func (db *merchantRepository) UpdateStockRepository(userId uuid.UUID, productId uuid.UUID, stock uint) *dto.ErrorResponse {
+	record := db.Model(&models.Products{}).Where("product_id = ? AND user_id = ?", productId, userId).Update("stock", stock)
 	if record.Error != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
 	} else if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: "something went wrong"}
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product not found on your listing"}
 	}
 
 	return nil
 }
 
-func (db *merchantRepository) UpdateMerchantRepository(user *models.Users) *dto.ErrorResponse {
-	var userExcist models.Users
+func (db *merchantRepository) UpdateOrderStatusRepository(orderId uuid.UUID, userId uuid.UUID, update dto.ShipmentUpdate) *dto.ErrorResponse {
+	return updateShipmentStatus(db.DB, orderId, userId, update, constants.MerchantRole)
+}
+
+func (db *merchantRepository) GetMerchantRepository(userId uuid.UUID) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
 
-	record := db.Where("user_id = ? ", user.UserId).First(&userExcist)
+	record := db.Where("user_id = ?", userId).First(&user)
 	if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
 			Error: "user not found"}
 	}
 
-	record = db.Where("user_id = ?", user.UserId).Updates(models.Users{
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		Password:  user.Password})
-	if record.Error != nil {
-		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	} else if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: "something went wrong"}
-	}
-
-	for _, data := range user.Address {
-		record = db.Where("address_id = ?", data.AddressId).Updates(models.Addresses{
-			DoorNo:  data.DoorNo,
-			Street:  data.Street,
-			City:    data.City,
-			State:   data.State,
-			ZipCode: data.ZipCode})
-		if record.Error != nil {
-			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-				Error: record.Error.Error()}
-		} else if record.RowsAffected == 0 {
-			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-				Error: "something went wrong"}
-		}
-	}
-
-	return nil
+	return &user, nil
 }
 
-func (db *merchantRepository) GetProductsRepository(filter map[string]string, userId uuid.UUID) (*[]models.Products, *dto.ErrorResponse) {
-	var products []models.Products
-
-	categoryName := filter["category_name"]
-	brandName := filter["brand_name"]
+func (db *merchantRepository) UpdatePasswordRepository(userId uuid.UUID, sessionId uuid.UUID, hashedPassword string) *dto.ErrorResponse {
+	return updatePassword(db.DB, userId, sessionId, hashedPassword)
+}
 
-	record := db.Raw(`SELECT * FROM getProducts_fn($1,$2,$3)`, userId, brandName, categoryName).Find(&products)
-	if record.Error != nil {
-		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	}
+func (db *merchantRepository) UpdateMerchantRepository(userId uuid.UUID, updates map[string]interface{}, addresses []models.Addresses) *dto.ErrorResponse {
+	return updateProfile(db.DB, userId, updates, addresses)
+}
 
-	return &products, nil
+func (db *merchantRepository) GetProductsRepository(filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	return listProducts(db.DB, filter)
 }
 
 func (db *merchantRepository) GetProductRepository(userId uuid.UUID, productId uuid.UUID) (*models.Products, *dto.ErrorResponse) {
@@ -176,13 +174,42 @@ func (db *merchantRepository) GetProductRepository(userId uuid.UUID, productId u
 	return &product, nil
 }
 
-func (db *merchantRepository) GetOrdersRepository(userId uuid.UUID) (*models.Orders, *dto.ErrorResponse) {
-	var orders models.Orders
+func (db *merchantRepository) GetOrdersRepository(userId uuid.UUID, filter dto.OrderFilter) (*[]models.Orders, *dto.ErrorResponse) {
+	var orders []models.Orders
 
-	record := db.Where("user_id= ?", userId).First(&orders)
-	if record.RowsAffected == 0 {
-		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
-			Error: "no orders avilable"}
+	merchantProducts := db.Model(&models.Products{}).Select("product_id").Where("user_id = ?", userId)
+	merchantOrders := db.Model(&models.OrderedItems{}).
+		Select("ordered_items.order_id").
+		Joins("INNER JOIN products ON products.product_id = ordered_items.product_id").
+		Where("products.user_id = ?", userId)
+
+	query := db.Preload("Products", "product_id IN (?)", merchantProducts).
+		Preload("Shipments", "merchant_id = ?", userId).
+		Where("order_id IN (?)", merchantOrders)
+	if filter.Status != "" {
+		query = query.Where("order_id IN (?)", db.Model(&models.Shipments{}).Select("order_id").Where("merchant_id = ? AND status = ?", userId, filter.Status))
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	record := query.Order("created_at DESC").Find(&orders)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	for index, order := range orders {
+		var totalAmount float64
+		for _, item := range order.Products {
+			totalAmount += item.Price * float64(item.Quantity)
+		}
+		orders[index].TotalAmount = totalAmount
 	}
 
 	return &orders, nil
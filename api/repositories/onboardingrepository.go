@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"errors"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IOnboardingRepository interface {
+	CreateMerchantApplicationRepository(*models.MerchantApplications) *dto.ErrorResponse
+	GetUserApplicationsRepository(uuid.UUID) (*[]models.MerchantApplications, *dto.ErrorResponse)
+	GetMerchantApplicationsRepository(string) (*[]models.MerchantApplications, *dto.ErrorResponse)
+	GetMerchantApplicationRepository(uuid.UUID) (*models.MerchantApplications, *dto.ErrorResponse)
+	ReviewMerchantApplicationRepository(uuid.UUID, uuid.UUID, string, string) *dto.ErrorResponse
+}
+
+type onboardingRepository struct {
+	*gorm.DB
+}
+
+func CommenceOnboardingRepository(db *gorm.DB) IOnboardingRepository {
+	return &onboardingRepository{db}
+}
+
+func (db *onboardingRepository) CreateMerchantApplicationRepository(application *models.MerchantApplications) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var (
+			user    models.Users
+			pending int64
+		)
+
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", application.UserId).First(&user)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if user.Role != constants.UserRole {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict, Error: "only users can apply to become a merchant"}
+			return nil
+		}
+
+		record = tx.Model(&models.MerchantApplications{}).Where("user_id = ? AND status = ?", application.UserId, constants.Pending).Count(&pending)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if pending > 0 {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict, Error: "an application is already pending review"}
+			return nil
+		}
+
+		return tx.Create(application).Error
+	})
+	if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return errResponse
+}
+
+func (db *onboardingRepository) GetUserApplicationsRepository(userId uuid.UUID) (*[]models.MerchantApplications, *dto.ErrorResponse) {
+	var applications []models.MerchantApplications
+
+	record := db.Preload("Documents").Where("user_id = ?", userId).Order("created_at DESC").Find(&applications)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &applications, nil
+}
+
+func (db *onboardingRepository) GetMerchantApplicationsRepository(status string) (*[]models.MerchantApplications, *dto.ErrorResponse) {
+	var applications []models.MerchantApplications
+
+	record := db.Preload("Documents").Where("status = ?", status).Order("created_at").Find(&applications)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &applications, nil
+}
+
+func (db *onboardingRepository) GetMerchantApplicationRepository(applicationId uuid.UUID) (*models.MerchantApplications, *dto.ErrorResponse) {
+	var application models.MerchantApplications
+
+	record := db.Preload("Documents").Where("application_id = ?", applicationId).First(&application)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "application does not exists"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &application, nil
+}
+
+func (db *onboardingRepository) ReviewMerchantApplicationRepository(applicationId uuid.UUID, reviewerId uuid.UUID, status string, reason string) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var application models.MerchantApplications
+		now := time.Now()
+
+		record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("application_id = ?", applicationId).First(&application)
+		if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusNotFound, Error: "application does not exists"}
+			return nil
+		} else if record.Error != nil {
+			return record.Error
+		}
+
+		if application.Status != constants.Pending {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict, Error: "application has already been reviewed"}
+			return nil
+		}
+
+		record = tx.Model(&application).Updates(map[string]interface{}{
+			"status":           status,
+			"rejection_reason": reason,
+			"reviewed_by":      reviewerId,
+			"reviewed_at":      now,
+		})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if status != constants.Approved {
+			return nil
+		}
+
+		record = tx.Model(&models.Users{}).Where("user_id = ? AND role = ?", application.UserId, constants.UserRole).Update("role", constants.MerchantRole)
+		if record.Error != nil {
+			return record.Error
+		} else if record.RowsAffected == 0 {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict, Error: "applicant is no longer eligible for promotion"}
+			return errors.New(errResponse.Error)
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND revoked_at IS NULL", application.UserId).Update("revoked_at", now).Error
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
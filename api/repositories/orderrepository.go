@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func deriveOrderStatus(shipments []models.Shipments) string {
+	orderStatus := constants.Cancelled
+
+	for _, shipment := range shipments {
+		if shipment.Status == constants.Cancelled {
+			continue
+		}
+
+		if orderStatus == constants.Cancelled || slices.Index(constants.OrderStatusSequence, shipment.Status) < slices.Index(constants.OrderStatusSequence, orderStatus) {
+			orderStatus = shipment.Status
+		}
+	}
+
+	return orderStatus
+}
+
+func lockOrder(tx *gorm.DB, orderId uuid.UUID) (*models.Orders, *dto.ErrorResponse) {
+	var order models.Orders
+
+	record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderId).First(&order)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "order not avilable"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &order, nil
+}
+
+func transitionShipmentStatus(tx *gorm.DB, shipment *models.Shipments, update dto.ShipmentUpdate, userId uuid.UUID, role string) *dto.ErrorResponse {
+	allowedRoles, ok := constants.OrderStatusTransitions[shipment.Status][update.Status]
+	if !ok {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: fmt.Sprintf("shipment cannot be moved from %s to %s", shipment.Status, update.Status)}
+	}
+
+	if !slices.Contains(allowedRoles, role) {
+		return &dto.ErrorResponse{Status: fiber.StatusForbidden,
+			Error: "insufficient permission to update specific status"}
+	}
+
+	updates := map[string]interface{}{"status": update.Status}
+	if update.TrackingNumber != "" {
+		updates["tracking_number"] = update.TrackingNumber
+	}
+
+	if update.Carrier != "" {
+		updates["carrier"] = update.Carrier
+	}
+
+	record := tx.Model(&models.Shipments{}).Where("shipment_id = ?", shipment.ShipmentId).Updates(updates)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	record = tx.Create(&models.OrderStatusHistory{
+		OrderId:    shipment.OrderId,
+		ShipmentId: &shipment.ShipmentId,
+		FromStatus: shipment.Status,
+		ToStatus:   update.Status,
+		ChangedBy:  userId,
+		Role:       role,
+	})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	shipment.Status = update.Status
+	if update.Status != constants.Cancelled {
+		return nil
+	}
+
+	for _, item := range shipment.Items {
+		record = tx.Model(&models.Products{}).Where("product_id = ?", item.ProductId).Update("stock", gorm.Expr("stock + ?", item.Quantity))
+		if record.Error != nil {
+			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+				Error: record.Error.Error()}
+		}
+	}
+
+	return nil
+}
+
+func syncOrderStatus(tx *gorm.DB, order *models.Orders, userId uuid.UUID, role string) *dto.ErrorResponse {
+	var shipments []models.Shipments
+
+	record := tx.Where("order_id = ?", order.OrderId).Find(&shipments)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	orderStatus := deriveOrderStatus(shipments)
+	if orderStatus == order.Status {
+		return nil
+	}
+
+	record = tx.Model(&models.Orders{}).Where("order_id = ?", order.OrderId).Update("status", orderStatus)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	record = tx.Create(&models.OrderStatusHistory{
+		OrderId:    order.OrderId,
+		FromStatus: order.Status,
+		ToStatus:   orderStatus,
+		ChangedBy:  userId,
+		Role:       role,
+	})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	order.Status = orderStatus
+	return nil
+}
+
+func runOrderTransaction(db *gorm.DB, fn func(*gorm.DB) *dto.ErrorResponse) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		errResponse = fn(tx)
+		if errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func updateShipmentStatus(db *gorm.DB, orderId uuid.UUID, merchantId uuid.UUID, update dto.ShipmentUpdate, role string) *dto.ErrorResponse {
+	return runOrderTransaction(db, func(tx *gorm.DB) *dto.ErrorResponse {
+		var shipment models.Shipments
+
+		order, errResponse := lockOrder(tx, orderId)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		record := tx.Preload("Items").Where("order_id = ? AND merchant_id = ?", orderId, merchantId).First(&shipment)
+		if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+			return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+				Error: "order not found on your listing"}
+		} else if record.Error != nil {
+			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+				Error: record.Error.Error()}
+		}
+
+		if errResponse := transitionShipmentStatus(tx, &shipment, update, merchantId, role); errResponse != nil {
+			return errResponse
+		}
+
+		return syncOrderStatus(tx, order, merchantId, role)
+	})
+}
+
+func updateOrderStatus(db *gorm.DB, orderId uuid.UUID, orderStatus string, userId uuid.UUID, role string) *dto.ErrorResponse {
+	return runOrderTransaction(db, func(tx *gorm.DB) *dto.ErrorResponse {
+		var shipments []models.Shipments
+
+		order, errResponse := lockOrder(tx, orderId)
+		if errResponse != nil {
+			return errResponse
+		}
+
+		if _, ok := constants.OrderStatusTransitions[order.Status][orderStatus]; !ok {
+			return &dto.ErrorResponse{Status: fiber.StatusConflict,
+				Error: fmt.Sprintf("order cannot be moved from %s to %s", order.Status, orderStatus)}
+		}
+
+		record := tx.Preload("Items").Where("order_id = ? AND status NOT IN ?", orderId, []string{constants.Cancelled, orderStatus}).Find(&shipments)
+		if record.Error != nil {
+			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+				Error: record.Error.Error()}
+		}
+
+		for index := range shipments {
+			errResponse := transitionShipmentStatus(tx, &shipments[index], dto.ShipmentUpdate{Status: orderStatus}, userId, role)
+			if errResponse != nil {
+				return errResponse
+			}
+		}
+
+		return syncOrderStatus(tx, order, userId, role)
+	})
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestUpdateOrderStatusEnforcesTransitions(t *testing.T) {
+	db := openTestDatabase(t)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	customer := createTestUser(t, db, constants.UserRole)
+	product := createTestProduct(t, db, merchant.UserId, models.Products{Price: 100, Stock: 5})
+	order := placeTestOrder(t, db, customer, models.OrderedItems{ProductId: product.ProductId, Quantity: 1})
+
+	tests := []struct {
+		name   string
+		status string
+		userId uuid.UUID
+		role   string
+		want   int
+	}{
+		{name: "customer ships", status: constants.Shipped, userId: customer.UserId, role: constants.UserRole, want: fiber.StatusForbidden},
+		{name: "merchant skips to delivered", status: constants.Delivered, userId: merchant.UserId, role: constants.MerchantRole, want: fiber.StatusConflict},
+		{name: "merchant ships", status: constants.Shipped, userId: merchant.UserId, role: constants.MerchantRole},
+		{name: "customer cancels shipped order", status: constants.Cancelled, userId: customer.UserId, role: constants.UserRole, want: fiber.StatusConflict},
+		{name: "merchant moves back to placed", status: constants.Placed, userId: merchant.UserId, role: constants.MerchantRole, want: fiber.StatusConflict},
+	}
+
+	for _, test := range tests {
+		status := 0
+		if errResponse := updateOrderStatus(db, order.OrderId, test.status, test.userId, test.role); errResponse != nil {
+			status = errResponse.Status
+		}
+
+		if status != test.want {
+			t.Fatalf("%s: status = %d, want %d", test.name, status, test.want)
+		}
+	}
+
+	var history []models.OrderStatusHistory
+	if err := db.Where("order_id = ?", order.OrderId).Find(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var shipped int
+	for _, entry := range history {
+		switch {
+		case entry.ToStatus == constants.Shipped && entry.FromStatus == constants.Placed && entry.ChangedBy == merchant.UserId && entry.Role == constants.MerchantRole:
+			shipped++
+		case entry.ToStatus != constants.Placed && entry.ToStatus != constants.Shipped:
+			t.Errorf("rejected transition was recorded: %s -> %s by %s", entry.FromStatus, entry.ToStatus, entry.Role)
+		}
+	}
+
+	if shipped == 0 {
+		t.Fatal("shipping the order was not recorded in the status history")
+	}
+}
+
+func TestCancelOrderRestoresStock(t *testing.T) {
+	db := openTestDatabase(t)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	customer := createTestUser(t, db, constants.UserRole)
+	product := createTestProduct(t, db, merchant.UserId, models.Products{Price: 100, Stock: 5})
+	order := placeTestOrder(t, db, customer, models.OrderedItems{ProductId: product.ProductId, Quantity: 2})
+
+	if errResponse := updateOrderStatus(db, order.OrderId, constants.Cancelled, customer.UserId, constants.UserRole); errResponse != nil {
+		t.Fatalf("cancel order: %s", errResponse.Error)
+	}
+
+	var stock uint
+	if err := db.Model(&models.Products{}).Select("stock").Where("product_id = ?", product.ProductId).Scan(&stock).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if stock != 5 {
+		t.Fatalf("stock after cancellation = %d, want 5", stock)
+	}
+
+	if errResponse := updateOrderStatus(db, order.OrderId, constants.Cancelled, customer.UserId, constants.UserRole); errResponse == nil || errResponse.Status != fiber.StatusConflict {
+		t.Fatalf("cancelling twice = %v, want a conflict", errResponse)
+	}
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPermissionRepository interface {
+	HasPermissionsRepository(string, []string) (bool, *dto.ErrorResponse)
+	GetPermissionsRepository() (*[]models.Permissions, *dto.ErrorResponse)
+	GetRolePermissionsRepository() (*[]models.RolePermissions, *dto.ErrorResponse)
+	GrantPermissionRepository(*models.RolePermissions) *dto.ErrorResponse
+	RevokePermissionRepository(string, string) *dto.ErrorResponse
+}
+
+type permissionRepository struct {
+	*gorm.DB
+}
+
+func CommencePermissionRepository(db *gorm.DB) IPermissionRepository {
+	return &permissionRepository{db}
+}
+
+func (db *permissionRepository) HasPermissionsRepository(role string, permissions []string) (bool, *dto.ErrorResponse) {
+	var granted int64
+
+	record := db.Model(&models.RolePermissions{}).Where("role = ? AND permission_name IN ?", role, permissions).Count(&granted)
+	if record.Error != nil {
+		return false, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return granted == int64(len(permissions)), nil
+}
+
+func (db *permissionRepository) GetPermissionsRepository() (*[]models.Permissions, *dto.ErrorResponse) {
+	var permissions []models.Permissions
+
+	record := db.Order("permission_name").Find(&permissions)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &permissions, nil
+}
+
+func (db *permissionRepository) GetRolePermissionsRepository() (*[]models.RolePermissions, *dto.ErrorResponse) {
+	var rolePermissions []models.RolePermissions
+
+	record := db.Order("role, permission_name").Find(&rolePermissions)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &rolePermissions, nil
+}
+
+func (db *permissionRepository) GrantPermissionRepository(rolePermission *models.RolePermissions) *dto.ErrorResponse {
+	var permission models.Permissions
+
+	record := db.Where("permission_name = ?", rolePermission.PermissionName).Limit(1).Find(&permission)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "permission does not exists"}
+	}
+
+	record = db.Clauses(clause.OnConflict{DoNothing: true}).Create(rolePermission)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *permissionRepository) RevokePermissionRepository(role string, permissionName string) *dto.ErrorResponse {
+	record := db.Where("role = ? AND permission_name = ?", role, permissionName).Delete(&models.RolePermissions{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "role does not have the permission"}
+	}
+
+	return nil
+}
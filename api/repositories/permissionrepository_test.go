@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"shopping-site/utils/constants"
+	"testing"
+)
+
+func TestHasPermissionsRepositoryRequiresEveryPermission(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommencePermissionRepository(db)
+
+	tests := []struct {
+		role        string
+		permissions []string
+		want        bool
+	}{
+		{role: constants.UserRole, permissions: []string{constants.CartManage}, want: true},
+		{role: constants.UserRole, permissions: []string{constants.CartManage, constants.OrderPlace}, want: true},
+		{role: constants.UserRole, permissions: []string{constants.CatalogManage}},
+		{role: constants.UserRole, permissions: []string{constants.CartManage, constants.CatalogManage}},
+		{role: constants.MerchantRole, permissions: []string{constants.ProductWrite}, want: true},
+		{role: constants.MerchantRole, permissions: []string{constants.OrderPlace}},
+	}
+
+	for _, test := range tests {
+		allowed, errResponse := repo.HasPermissionsRepository(test.role, test.permissions)
+		if errResponse != nil {
+			t.Fatalf("HasPermissionsRepository(%s, %v): %s", test.role, test.permissions, errResponse.Error)
+		}
+
+		if allowed != test.want {
+			t.Errorf("HasPermissionsRepository(%s, %v) = %v, want %v", test.role, test.permissions, allowed, test.want)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type productSort struct {
+	column string
+	cast   string
+	cursor string
+	desc   bool
+}
+
+type productRow struct {
+	models.Products
+	CursorValue string
+	Facets      string
+}
+
+var numericCursorPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+func validateCursorValue(cast string, value string) error {
+	if cast == "timestamptz" {
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err
+	}
+
+	if !numericCursorPattern.MatchString(value) {
+		return errors.New("invalid numeric cursor value")
+	}
+
+	return nil
+}
+
+var productSorts = map[string]productSort{
+	constants.SortNewest:    {column: "products.created_at", cast: "timestamptz", cursor: `to_char(products.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`, desc: true},
+	constants.SortPriceAsc:  {column: "products.price", cast: "numeric", cursor: "products.price::text"},
+	constants.SortPriceDesc: {column: "products.price", cast: "numeric", cursor: "products.price::text", desc: true},
+	constants.SortRating:    {column: "products.rating", cast: "numeric", cursor: "products.rating::text", desc: true},
+}
+
+func filterProducts(db *gorm.DB, filter dto.ProductFilter) *gorm.DB {
+	query := db.Model(&models.Products{})
+
+	if filter.ApprovedOnly {
+		query = query.Where("products.is_approved = ?", true)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("products.approval_status = ?", filter.Status)
+	}
+
+	if filter.MerchantId != uuid.Nil {
+		query = query.Where("products.user_id = ?", filter.MerchantId)
+	}
+
+	if filter.Brand != "" {
+		query = query.Where("products.brand_id IN (?)", db.Model(&models.Brands{}).Select("brand_id").Where("slug = ?", filter.Brand))
+	}
+
+	if filter.BrandName != "" {
+		query = query.Where("products.brand_id IN (?)", db.Model(&models.Brands{}).Select("brand_id").Where("brand_name = ?", filter.BrandName))
+	}
+
+	if filter.Category != "" {
+		rootCategory := db.Model(&models.Categories{}).Select("category_id").Where("slug = ?", filter.Category)
+		query = query.Where("products.category_id IN ("+categoryDescendantsQuery+")", rootCategory)
+	}
+
+	if filter.CategoryName != "" {
+		query = query.Where("products.category_id IN (?)", db.Model(&models.Categories{}).Select("category_id").Where("category_name = ?", filter.CategoryName))
+	}
+
+	if filter.MinPrice > 0 {
+		query = query.Where("products.price >= ?", filter.MinPrice)
+	}
+
+	if filter.MaxPrice > 0 {
+		query = query.Where("products.price <= ?", filter.MaxPrice)
+	}
+
+	if filter.Rating > 0 {
+		query = query.Where("products.rating >= ?", filter.Rating)
+	}
+
+	return query
+}
+
+const (
+	brandFacetSet    = 7
+	categoryFacetSet = 11
+	priceFacetSet    = 13
+	ratingFacetSet   = 14
+	totalFacetSet    = 15
+)
+
+const productListQuery = `WITH filtered AS (?),
+	facets AS (
+		SELECT f.brand_id, f.category_id, f.price_bucket, f.rating_bucket,
+			GROUPING(f.brand_id, f.category_id, f.price_bucket, f.rating_bucket) AS grouping_set, COUNT(*) AS count
+		FROM filtered AS f
+		GROUP BY GROUPING SETS (%s)
+	),
+	summary AS (
+		SELECT COALESCE(json_agg(json_build_object(
+				'brand_id', facets.brand_id, 'category_id', facets.category_id,
+				'price_bucket', facets.price_bucket, 'rating_bucket', facets.rating_bucket,
+				'grouping_set', facets.grouping_set, 'count', facets.count,
+				'brand_name', COALESCE(b.brand_name, ''), 'category_name', COALESCE(c.category_name, ''))
+			ORDER BY facets.grouping_set, facets.price_bucket NULLS FIRST, facets.rating_bucket NULLS FIRST, facets.count DESC), '[]')::text AS facets
+		FROM facets
+		LEFT JOIN brands AS b ON b.brand_id = facets.brand_id AND b.deleted_at IS NULL
+		LEFT JOIN categories AS c ON c.category_id = facets.category_id AND c.deleted_at IS NULL
+		WHERE (facets.brand_id IS NULL OR b.brand_id IS NOT NULL) AND (facets.category_id IS NULL OR c.category_id IS NOT NULL)
+	),
+	page AS (?)
+	SELECT page.*, summary.facets FROM summary LEFT JOIN page ON true ORDER BY %s`
+
+type productFacetRow struct {
+	BrandId      *uuid.UUID `json:"brand_id"`
+	CategoryId   *uuid.UUID `json:"category_id"`
+	PriceBucket  *int       `json:"price_bucket"`
+	RatingBucket *int       `json:"rating_bucket"`
+	GroupingSet  int        `json:"grouping_set"`
+	Count        int64      `json:"count"`
+	BrandName    string     `json:"brand_name"`
+	CategoryName string     `json:"category_name"`
+}
+
+func priceBucketBounds() string {
+	bounds := make([]string, len(constants.PriceBuckets))
+	for i, bound := range constants.PriceBuckets {
+		bounds[i] = strconv.FormatFloat(bound, 'f', -1, 64)
+	}
+
+	return "{" + strings.Join(bounds, ",") + "}"
+}
+
+func priceBucketFacet(bucket int, count int64) dto.FacetCount {
+	facet := dto.FacetCount{Min: new(float64), Count: count}
+	if bucket > 0 {
+		*facet.Min = constants.PriceBuckets[bucket-1]
+	}
+
+	label := strconv.FormatFloat(*facet.Min, 'f', -1, 64)
+	if bucket < len(constants.PriceBuckets) {
+		upper := constants.PriceBuckets[bucket]
+		facet.Max = &upper
+		facet.Label = label + "-" + strconv.FormatFloat(*facet.Max, 'f', -1, 64)
+	} else {
+		facet.Label = label + "+"
+	}
+
+	return facet
+}
+
+func ratingBucketFacet(bucket int, count int64) dto.FacetCount {
+	lower, upper := float64(bucket), float64(bucket+1)
+	return dto.FacetCount{Label: strconv.Itoa(bucket) + "-" + strconv.Itoa(bucket+1), Min: &lower, Max: &upper, Count: count}
+}
+
+func productFacets(rows []productFacetRow) (*dto.ProductFacets, int64) {
+	var total int64
+	facets := dto.ProductFacets{Brands: []dto.FacetCount{}, Categories: []dto.FacetCount{}, Prices: []dto.FacetCount{}, Ratings: []dto.FacetCount{}}
+
+	for _, row := range rows {
+		switch row.GroupingSet {
+		case brandFacetSet:
+			if row.BrandId != nil {
+				facets.Brands = append(facets.Brands, dto.FacetCount{Id: row.BrandId, Label: row.BrandName, Count: row.Count})
+			}
+		case categoryFacetSet:
+			if row.CategoryId != nil {
+				facets.Categories = append(facets.Categories, dto.FacetCount{Id: row.CategoryId, Label: row.CategoryName, Count: row.Count})
+			}
+		case priceFacetSet:
+			if row.PriceBucket != nil {
+				facets.Prices = append(facets.Prices, priceBucketFacet(*row.PriceBucket, row.Count))
+			}
+		case ratingFacetSet:
+			if row.RatingBucket != nil {
+				facets.Ratings = append(facets.Ratings, ratingBucketFacet(*row.RatingBucket, row.Count))
+			}
+		case totalFacetSet:
+			total = row.Count
+		}
+	}
+
+	return &facets, total
+}
+
+func encodeProductCursor(value string, productId uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value + "|" + productId.String()))
+}
+
+func decodeProductCursor(cursor string) (string, uuid.UUID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	value, id, _ := strings.Cut(string(decoded), "|")
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	return value, productId, nil
+}
+
+func listProducts(db *gorm.DB, filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	var (
+		rows        []productRow
+		facetRows   []productFacetRow
+		cursorValue string
+		cursorId    uuid.UUID
+	)
+	sort := productSorts[filter.Sort]
+	response := dto.PaginatedResponse{Page: filter.Page, Limit: filter.Limit}
+
+	direction, comparison := "ASC", ">"
+	if sort.desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.Cursor != "" {
+		value, productId, err := decodeProductCursor(filter.Cursor)
+		if err != nil || validateCursorValue(sort.cast, value) != nil {
+			return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: "invalid cursor"}
+		}
+
+		cursorValue, cursorId = value, productId
+	}
+
+	groupingSets := "()"
+	if filter.Facets {
+		groupingSets = "(f.brand_id), (f.category_id), (f.price_bucket), (f.rating_bucket), ()"
+	}
+
+	filtered := filterProducts(db, filter).Select(`products.brand_id, products.category_id,
+		width_bucket(products.price::double precision, ?::double precision[]) AS price_bucket,
+		LEAST(FLOOR(products.rating), 4)::int AS rating_bucket`, priceBucketBounds())
+
+	page := filterProducts(db, filter).Select("products.*, " + sort.cursor + " AS cursor_value").Order(sort.column + " " + direction + ", products.product_id " + direction).Limit(filter.Limit + 1)
+	if filter.Cursor != "" {
+		page = page.Where("("+sort.column+", products.product_id) "+comparison+" (CAST(? AS "+sort.cast+"), ?)", cursorValue, cursorId)
+	} else {
+		page = page.Offset((filter.Page - 1) * filter.Limit)
+	}
+
+	pageOrder := "page." + strings.TrimPrefix(sort.column, "products.") + " " + direction + ", page.product_id " + direction
+	record := db.Raw(fmt.Sprintf(productListQuery, groupingSets, pageOrder), filtered, page).Scan(&rows)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if len(rows) == 0 {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: "something went wrong"}
+	}
+
+	if err := json.Unmarshal([]byte(rows[0].Facets), &facetRows); err != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	facets, total := productFacets(facetRows)
+	if filter.Facets {
+		response.Facets = facets
+	}
+	response.Total = total
+
+	if rows[0].ProductId == uuid.Nil {
+		rows = nil
+	}
+
+	if len(rows) > filter.Limit {
+		rows = rows[:filter.Limit]
+		last := rows[len(rows)-1]
+		response.NextCursor = encodeProductCursor(last.CursorValue, last.ProductId)
+	}
+
+	products := make([]models.Products, len(rows))
+	for i, row := range rows {
+		products[i] = row.Products
+	}
+
+	response.Items = products
+	return &response, nil
+}
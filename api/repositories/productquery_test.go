@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestListProductsCursorWithTiedRatings(t *testing.T) {
+	db := openTestDatabase(t)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	category, brand := createTestCatalog(t, db)
+
+	var want []models.Products
+	for _, product := range []models.Products{
+		{Rating: 4.2, Price: 100}, {Rating: 3.7, Price: 100}, {Rating: 3.7, Price: 100},
+		{Rating: 4.2, Price: 100}, {Rating: 3.7, Price: 100},
+	} {
+		product.CategoryId, product.BrandId = category.CategoryId, brand.BrandId
+		want = append(want, createTestProduct(t, db, merchant.UserId, product))
+	}
+
+	slices.SortFunc(want, func(a models.Products, b models.Products) int {
+		if a.Rating != b.Rating {
+			if a.Rating > b.Rating {
+				return -1
+			}
+			return 1
+		}
+		return -strings.Compare(a.ProductId.String(), b.ProductId.String())
+	})
+
+	var got []uuid.UUID
+	filter := dto.ProductFilter{MerchantId: merchant.UserId, Sort: constants.SortRating, Page: 1, Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatalf("pagination did not terminate, collected %d products", len(got))
+		}
+
+		response, errResponse := listProducts(db, filter)
+		if errResponse != nil {
+			t.Fatalf("list products: %s", errResponse.Error)
+		}
+
+		for _, product := range response.Items.([]models.Products) {
+			got = append(got, product.ProductId)
+		}
+
+		if response.NextCursor == "" {
+			break
+		}
+		filter.Cursor = response.NextCursor
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("collected %d products, want %d", len(got), len(want))
+	}
+
+	for i, product := range want {
+		if got[i] != product.ProductId {
+			t.Fatalf("product %d = %s, want %s", i, got[i], product.ProductId)
+		}
+	}
+}
+
+func TestProductFacetsUsesGroupingSet(t *testing.T) {
+	brandId, categoryId := uuid.New(), uuid.New()
+	priceBucket, ratingBucket := 1, 4
+
+	rows := []productFacetRow{
+		{GroupingSet: totalFacetSet, Count: 9},
+		{GroupingSet: brandFacetSet, Count: 3},
+		{GroupingSet: brandFacetSet, BrandId: &brandId, BrandName: "Acme", Count: 6},
+		{GroupingSet: categoryFacetSet, CategoryId: &categoryId, CategoryName: "Kitchen", Count: 9},
+		{GroupingSet: priceFacetSet, PriceBucket: &priceBucket, Count: 9},
+		{GroupingSet: ratingFacetSet, RatingBucket: &ratingBucket, Count: 9},
+		{GroupingSet: ratingFacetSet, Count: 2},
+	}
+
+	facets, total := productFacets(rows)
+	if total != 9 {
+		t.Fatalf("total = %d, want 9", total)
+	}
+
+	if len(facets.Brands) != 1 || *facets.Brands[0].Id != brandId || facets.Brands[0].Count != 6 {
+		t.Fatalf("brands = %+v, want only Acme", facets.Brands)
+	}
+
+	if len(facets.Categories) != 1 || len(facets.Prices) != 1 || len(facets.Ratings) != 1 {
+		t.Fatalf("facets = %+v, want one category, price and rating bucket", facets)
+	}
+
+	if facets.Ratings[0].Label != "4-5" {
+		t.Fatalf("rating label = %q, want 4-5", facets.Ratings[0].Label)
+	}
+
+	if bounds := priceBucketBounds(); bounds != "{500,1000,5000,10000,50000}" {
+		t.Fatalf("priceBucketBounds() = %q, want a Postgres array literal", bounds)
+	}
+}
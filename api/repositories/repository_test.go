@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"shopping-site/internals"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var migrateOnce sync.Once
+
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	discard := log.New(io.Discard, "", 0)
+	loggers.InfoLog, loggers.WarnLog, loggers.ErrorLog = discard, discard, discard
+	loggers.FatalLog = log.New(os.Stderr, "FATAL:", log.LstdFlags)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true, Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+
+	migrateOnce.Do(func() { internals.SchemaMigration(db) })
+	return db
+}
+
+func uniqueSuffix() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")
+}
+
+func createTestUser(t *testing.T, db *gorm.DB, role string) models.Users {
+	t.Helper()
+
+	suffix := uniqueSuffix()
+	user := models.Users{
+		FirstName: "Test",
+		LastName:  "User",
+		Email:     suffix + "@example.com",
+		Phone:     fmt.Sprintf("%010d", rand.Int64N(10_000_000_000)),
+		Password:  "unused",
+		Role:      role,
+	}
+	if role == constants.UserRole {
+		user.Address = []models.Addresses{{DoorNo: "1", Street: "Main Street", City: "Chennai", State: "Tamil Nadu", ZipCode: 600001, IsDefaultShipping: true, IsDefaultBilling: true}}
+	}
+
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	return user
+}
+
+func createTestCatalog(t *testing.T, db *gorm.DB) (models.Categories, models.Brands) {
+	t.Helper()
+
+	suffix := uniqueSuffix()
+	category := models.Categories{CategoryName: "Category " + suffix, Slug: "category-" + suffix}
+	brand := models.Brands{BrandName: "Brand " + suffix, Slug: "brand-" + suffix}
+
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+
+	if err := db.Create(&brand).Error; err != nil {
+		t.Fatalf("failed to create brand: %v", err)
+	}
+
+	return category, brand
+}
+
+func createTestProduct(t *testing.T, db *gorm.DB, merchantId uuid.UUID, product models.Products) models.Products {
+	t.Helper()
+
+	if product.CategoryId == uuid.Nil || product.BrandId == uuid.Nil {
+		category, brand := createTestCatalog(t, db)
+		product.CategoryId, product.BrandId = category.CategoryId, brand.BrandId
+	}
+
+	if product.ProductName == "" {
+		product.ProductName = "Product " + uniqueSuffix()
+	}
+
+	product.UserId = merchantId
+	product.IsApproved = true
+	product.ApprovalStatus = constants.Approved
+
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+
+	return product
+}
+
+func placeTestOrder(t *testing.T, db *gorm.DB, customer models.Users, items ...models.OrderedItems) models.Orders {
+	t.Helper()
+
+	order, errResponse := CommenceUserRepository(db).PlaceOrderRepository(customer.UserId, models.Orders{AddressId: customer.Address[0].AddressId, Products: items})
+	if errResponse != nil {
+		t.Fatalf("failed to place order: %s", errResponse.Error)
+	}
+
+	return *order
+}
@@ -0,0 +1,417 @@
+package repositories
+
+import (
+	"errors"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReviewRepository interface {
+	GetProductReviewsRepository(uuid.UUID) (*[]models.Reviews, *dto.ErrorResponse)
+	AddReviewRepository(*models.Reviews) *dto.ErrorResponse
+	UpdateReviewRepository(*models.Reviews) *dto.ErrorResponse
+	DeleteReviewRepository(uuid.UUID, uuid.UUID) *dto.ErrorResponse
+	ReplyReviewRepository(uuid.UUID, uuid.UUID, string) (*models.Reviews, *dto.ErrorResponse)
+	ReportReviewRepository(*models.ReviewReports) *dto.ErrorResponse
+	GetModerationQueueRepository() (*[]models.Reviews, *dto.ErrorResponse)
+	ModerateReviewRepository(uuid.UUID, uuid.UUID, string) *dto.ErrorResponse
+	RemoveReviewRepository(uuid.UUID) *dto.ErrorResponse
+	GetBannedWordsRepository() (*[]models.BannedWords, *dto.ErrorResponse)
+	AddBannedWordRepository(*models.BannedWords) *dto.ErrorResponse
+	DeleteBannedWordRepository(string) *dto.ErrorResponse
+}
+
+type reviewRepository struct {
+	*gorm.DB
+}
+
+func CommenceReviewRepository(db *gorm.DB) IReviewRepository {
+	return &reviewRepository{db}
+}
+
+func lockReviewedProduct(tx *gorm.DB, productId uuid.UUID) *dto.ErrorResponse {
+	var product models.Products
+
+	record := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ? AND is_approved = ?", productId, true).First(&product)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product does not exists"}
+	} else if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func refreshProductRating(tx *gorm.DB, productId uuid.UUID) *dto.ErrorResponse {
+	record := tx.Exec(`UPDATE products SET
+		rating = COALESCE((SELECT ROUND(AVG(stars), 2) FROM reviews WHERE product_id = @product AND status = @status AND deleted_at IS NULL), 0),
+		review_count = (SELECT COUNT(*) FROM reviews WHERE product_id = @product AND status = @status AND deleted_at IS NULL)
+		WHERE product_id = @product`, map[string]interface{}{"product": productId, "status": constants.Published})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) GetProductReviewsRepository(productId uuid.UUID) (*[]models.Reviews, *dto.ErrorResponse) {
+	var reviews []models.Reviews
+
+	record := db.Where("product_id = ? AND status = ?", productId, constants.Published).Order("created_at DESC").Find(&reviews)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &reviews, nil
+}
+
+func (db *reviewRepository) AddReviewRepository(review *models.Reviews) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		if errResponse = lockReviewedProduct(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		record := tx.Model(&models.OrderedItems{}).
+			Joins("INNER JOIN shipments AS s ON s.shipment_id = ordered_items.shipment_id").
+			Joins("INNER JOIN orders AS o ON o.order_id = ordered_items.order_id").
+			Where("o.user_id = ? AND ordered_items.product_id = ? AND s.status = ?", review.UserId, review.ProductId, constants.Delivered).
+			Count(&count)
+		if record.Error != nil {
+			return record.Error
+		} else if count == 0 {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusForbidden,
+				Error: "only customers with a delivered order can review this product"}
+			return errors.New(errResponse.Error)
+		}
+
+		record = tx.Model(&models.Reviews{}).Where("product_id = ? AND user_id = ?", review.ProductId, review.UserId).Count(&count)
+		if record.Error != nil {
+			return record.Error
+		} else if count > 0 {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict,
+				Error: "product already reviewed"}
+			return errors.New(errResponse.Error)
+		}
+
+		if err := tx.Create(review).Error; errors.Is(err, gorm.ErrDuplicatedKey) {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict,
+				Error: "product already reviewed"}
+			return err
+		} else if err != nil {
+			return err
+		}
+
+		if errResponse = refreshProductRating(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func lockReview(tx *gorm.DB, query string, args ...interface{}) (*models.Reviews, *dto.ErrorResponse) {
+	var review models.Reviews
+
+	record := tx.Where(query, args...).First(&review)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "review not found"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	record = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", review.ProductId).First(&models.Products{})
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &review, nil
+}
+
+func (db *reviewRepository) UpdateReviewRepository(review *models.Reviews) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var existing *models.Reviews
+
+		existing, errResponse = lockReview(tx, "review_id = ? AND user_id = ?", review.ReviewId, review.UserId)
+		if errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		existing.Stars = review.Stars
+		existing.Title = review.Title
+		existing.Body = review.Body
+
+		record := tx.Model(existing).Select("stars", "title", "body").Updates(existing)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		*review = *existing
+		if errResponse = refreshProductRating(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) DeleteReviewRepository(userId uuid.UUID, reviewId uuid.UUID) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var review *models.Reviews
+
+		review, errResponse = lockReview(tx, "review_id = ? AND user_id = ?", reviewId, userId)
+		if errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		if err := tx.Delete(review).Error; err != nil {
+			return err
+		}
+
+		if errResponse = refreshProductRating(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) ReplyReviewRepository(merchantId uuid.UUID, reviewId uuid.UUID, reply string) (*models.Reviews, *dto.ErrorResponse) {
+	var review models.Reviews
+
+	record := db.Joins("INNER JOIN products AS p ON p.product_id = reviews.product_id").
+		Where("reviews.review_id = ? AND p.user_id = ?", reviewId, merchantId).
+		First(&review)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "review not found on your products"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	if review.Status != constants.Published {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "only published reviews can be replied to"}
+	}
+
+	repliedAt := time.Now()
+	review.MerchantReply = reply
+	review.RepliedAt = &repliedAt
+
+	record = db.Model(&review).Where("status = ?", constants.Published).Select("merchant_reply", "replied_at").Updates(&review)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "only published reviews can be replied to"}
+	}
+
+	return &review, nil
+}
+
+func (db *reviewRepository) ReportReviewRepository(report *models.ReviewReports) *dto.ErrorResponse {
+	var (
+		review models.Reviews
+		count  int64
+	)
+
+	record := db.Where("review_id = ? AND status = ?", report.ReviewId, constants.Published).First(&review)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "review not found"}
+	} else if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	if review.UserId == report.UserId {
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "cannot report your own review"}
+	}
+
+	record = db.Model(&models.ReviewReports{}).Where("review_id = ? AND user_id = ?", report.ReviewId, report.UserId).Count(&count)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if count > 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "review already reported"}
+	}
+
+	record = db.Create(report)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) GetModerationQueueRepository() (*[]models.Reviews, *dto.ErrorResponse) {
+	var reviews []models.Reviews
+
+	pending := db.Model(&models.ReviewReports{}).Select("review_id").Where("status = ?", constants.Pending)
+	record := db.Preload("Reports", "status = ?", constants.Pending).Where("review_id IN (?)", pending).Order("created_at").Find(&reviews)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &reviews, nil
+}
+
+func (db *reviewRepository) ModerateReviewRepository(adminId uuid.UUID, reviewId uuid.UUID, status string) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var review *models.Reviews
+
+		review, errResponse = lockReview(tx, "review_id = ?", reviewId)
+		if errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		if err := tx.Model(review).Update("status", status).Error; err != nil {
+			return err
+		}
+
+		record := tx.Model(&models.ReviewReports{}).Where("review_id = ? AND status = ?", reviewId, constants.Pending).Updates(map[string]interface{}{
+			"status":      constants.Resolved,
+			"resolved_by": adminId,
+			"resolved_at": time.Now(),
+		})
+		if record.Error != nil {
+			return record.Error
+		}
+
+		if errResponse = refreshProductRating(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) RemoveReviewRepository(reviewId uuid.UUID) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var review *models.Reviews
+
+		review, errResponse = lockReview(tx, "review_id = ?", reviewId)
+		if errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		if err := tx.Delete(review).Error; err != nil {
+			return err
+		}
+
+		if errResponse = refreshProductRating(tx, review.ProductId); errResponse != nil {
+			return errors.New(errResponse.Error)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) GetBannedWordsRepository() (*[]models.BannedWords, *dto.ErrorResponse) {
+	var words []models.BannedWords
+
+	record := db.Order("word").Find(&words)
+	if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	}
+
+	return &words, nil
+}
+
+func (db *reviewRepository) AddBannedWordRepository(word *models.BannedWords) *dto.ErrorResponse {
+	record := db.Clauses(clause.OnConflict{DoNothing: true}).Create(word)
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "word already banned"}
+	}
+
+	return nil
+}
+
+func (db *reviewRepository) DeleteBannedWordRepository(word string) *dto.ErrorResponse {
+	record := db.Where("word = ?", word).Delete(&models.BannedWords{})
+	if record.Error != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
+	} else if record.RowsAffected == 0 {
+		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "word is not banned"}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"errors"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func TestAddReviewRepositoryRequiresDeliveredOrder(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommenceReviewRepository(db)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	customer := createTestUser(t, db, constants.UserRole)
+	product := createTestProduct(t, db, merchant.UserId, models.Products{Price: 100, Stock: 5})
+
+	review := models.Reviews{ProductId: product.ProductId, UserId: customer.UserId, Stars: 4, Title: "Solid"}
+	if errResponse := repo.AddReviewRepository(&review); errResponse == nil || errResponse.Status != fiber.StatusForbidden {
+		t.Fatalf("review without an order = %v, want forbidden", errResponse)
+	}
+
+	order := placeTestOrder(t, db, customer, models.OrderedItems{ProductId: product.ProductId, Quantity: 1})
+	if errResponse := repo.AddReviewRepository(&review); errResponse == nil || errResponse.Status != fiber.StatusForbidden {
+		t.Fatalf("review before delivery = %v, want forbidden", errResponse)
+	}
+
+	for _, status := range []string{constants.Shipped, constants.OutForDelivery, constants.Delivered} {
+		if errResponse := updateOrderStatus(db, order.OrderId, status, merchant.UserId, constants.MerchantRole); errResponse != nil {
+			t.Fatalf("move order to %s: %s", status, errResponse.Error)
+		}
+	}
+
+	if errResponse := repo.AddReviewRepository(&review); errResponse != nil {
+		t.Fatalf("review after delivery: %s", errResponse.Error)
+	}
+
+	var reviewed models.Products
+	if err := db.Where("product_id = ?", product.ProductId).First(&reviewed).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if reviewed.Rating != 4 || reviewed.ReviewCount != 1 {
+		t.Fatalf("product rating = %v from %d reviews, want 4 from 1", reviewed.Rating, reviewed.ReviewCount)
+	}
+
+	again := models.Reviews{ProductId: product.ProductId, UserId: customer.UserId, Stars: 1, Title: "Again"}
+	if errResponse := repo.AddReviewRepository(&again); errResponse == nil || errResponse.Status != fiber.StatusConflict {
+		t.Fatalf("second review = %v, want a conflict", errResponse)
+	}
+}
+
+func TestReviewsUniquePerUserAndProduct(t *testing.T) {
+	db := openTestDatabase(t)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	customer := createTestUser(t, db, constants.UserRole)
+	product := createTestProduct(t, db, merchant.UserId, models.Products{Price: 100})
+
+	first := models.Reviews{ProductId: product.ProductId, UserId: customer.UserId, Stars: 4, Title: "Good"}
+	if err := db.Create(&first).Error; err != nil {
+		t.Fatalf("create review: %v", err)
+	}
+
+	duplicate := models.Reviews{ProductId: product.ProductId, UserId: customer.UserId, Stars: 1, Title: "Again"}
+	if err := db.Create(&duplicate).Error; !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("second review for the same product = %v, want a duplicate key error", err)
+	}
+
+	if err := db.Delete(&first).Error; err != nil {
+		t.Fatalf("delete review: %v", err)
+	}
+
+	replacement := models.Reviews{ProductId: product.ProductId, UserId: customer.UserId, Stars: 5, Title: "Changed my mind"}
+	if err := db.Create(&replacement).Error; err != nil {
+		t.Fatalf("review after deleting the previous one: %v", err)
+	}
+}
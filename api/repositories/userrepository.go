@@ -1,26 +1,39 @@
 package repositories
 
 import (
+	"errors"
+	"fmt"
 	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
 	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserRepository interface {
-	UpdateUserRepository(*models.Users) *dto.ErrorResponse
+	GetUserRepository(uuid.UUID) (*models.Users, *dto.ErrorResponse)
+	UpdateUserRepository(uuid.UUID, map[string]interface{}, []models.Addresses) *dto.ErrorResponse
+	UpdatePasswordRepository(uuid.UUID, uuid.UUID, string) *dto.ErrorResponse
 	PlaceOrderRepository(uuid.UUID, models.Orders) (*models.Orders, *dto.ErrorResponse)
+	CheckoutRepository(uuid.UUID, models.Orders, []uuid.UUID) (*models.Orders, *dto.ErrorResponse)
 	CancelOrderRepository(uuid.UUID, uuid.UUID) *dto.ErrorResponse
 	GetOrdersRepository(uuid.UUID) (*[]models.Orders, *dto.ErrorResponse)
-	GetProductsRepository(map[string]string, uuid.UUID) (*[]models.Products, *dto.ErrorResponse)
+	GetProductsRepository(dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
 	GetProductRepository(uuid.UUID, uuid.UUID) (*models.Products, *dto.ErrorResponse)
-	FilterProductsRepository(map[string]string) (*[]models.Products, *dto.ErrorResponse)
+	SearchProductsRepository(dto.ProductSearchFilter, string) (*[]dto.ProductSearchResult, int64, *dto.ErrorResponse)
 }
 
+const productSearchCondition = `p.is_approved AND (p.search_vector @@ to_tsquery('simple', @tsquery)
+	OR @query <% p.product_name)`
+
+const escapeHTML = `replace(replace(replace(replace(replace(%s, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
 type userRepository struct {
 	*gorm.DB
 }
@@ -30,103 +43,244 @@ func CommenceUserRepository(db *gorm.DB) IUserRepository {
 }
 
 func (db *userRepository) PlaceOrderRepository(userId uuid.UUID, order models.Orders) (*models.Orders, *dto.ErrorResponse) {
-	var (
-		userDetails    models.Users
-		orderItems     []models.OrderedItems
-		addressDetails models.Addresses
-		totalAmount    float64
-	)
+	return db.placeOrder(userId, order, nil)
+}
 
-	record := db.Where("address_id= ? AND user_id= ?", order.AddressId, userId).First(&addressDetails)
-	if record.Error != nil {
-		loggers.WarnLog.Println("specified address not avilable on user profile")
-		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
-			Error: record.Error.Error()}
-	}
+func (db *userRepository) CheckoutRepository(userId uuid.UUID, order models.Orders, cartItemIds []uuid.UUID) (*models.Orders, *dto.ErrorResponse) {
+	return db.placeOrder(userId, order, func(tx *gorm.DB) error {
+		return tx.Where("cart_item_id IN ? AND cart_id IN (?)", cartItemIds, tx.Model(&models.Carts{}).Select("cart_id").Where("user_id = ?", userId)).Delete(&models.CartItems{}).Error
+	})
+}
 
-	for _, item := range order.Products {
-		var productDetails models.Products
+func (db *userRepository) placeOrder(userId uuid.UUID, order models.Orders, finalize func(*gorm.DB) error) (*models.Orders, *dto.ErrorResponse) {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var (
+			userDetails    models.Users
+			products       []models.Products
+			addressDetails models.Addresses
+			productIds     []uuid.UUID
+			outOfStock     []uuid.UUID
+			merchantIds    []uuid.UUID
+			totalAmount    float64
+		)
+		shipmentItems := make(map[uuid.UUID][]models.OrderedItems)
+
+		record := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("address_id= ? AND user_id= ?", order.AddressId, userId).First(&addressDetails)
+		if record.Error != nil {
+			loggers.WarnLog.Println("specified address not avilable on user profile")
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: record.Error.Error()}
+			return record.Error
+		}
+
+		for _, item := range order.Products {
+			productIds = append(productIds, item.ProductId)
+		}
 
-		record = db.Where("product_id= ?", item.ProductId).First(&productDetails)
+		record = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id IN ? AND is_approved = ?", productIds, true).Order("product_id").Find(&products)
 		if record.Error != nil {
 			loggers.ErrorLog.Println("error while getting product details")
-			return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 				Error: record.Error.Error()}
+			return record.Error
 		}
-		itemAmount := productDetails.Price * float64(item.Quantity)
-		totalAmount += itemAmount
 
-		item.ProductName = productDetails.ProductName
-		item.Price = productDetails.Price
+		productDetails := make(map[uuid.UUID]*models.Products, len(products))
+		for index := range products {
+			productDetails[products[index].ProductId] = &products[index]
+		}
 
-		orderItems = append(orderItems, item)
-	}
+		for _, item := range order.Products {
+			product, ok := productDetails[item.ProductId]
+			if !ok {
+				errResponse = &dto.ErrorResponse{Status: fiber.StatusNotFound,
+					Error: "product does not exists",
+					Data:  map[string]interface{}{"product_ids": []uuid.UUID{item.ProductId}}}
+				return errors.New(errResponse.Error)
+			}
+
+			if product.Stock < item.Quantity {
+				outOfStock = append(outOfStock, item.ProductId)
+				continue
+			}
+			product.Stock -= item.Quantity
+
+			itemAmount := product.Price * float64(item.Quantity)
+			totalAmount += itemAmount
+
+			item.ProductName = product.ProductName
+			item.Price = product.Price
+
+			if _, ok := shipmentItems[product.UserId]; !ok {
+				merchantIds = append(merchantIds, product.UserId)
+			}
+			shipmentItems[product.UserId] = append(shipmentItems[product.UserId], item)
+		}
 
-	record = db.Where("user_id= ?", userId).First(&userDetails)
-	if record.Error != nil {
-		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	}
+		if len(outOfStock) > 0 {
+			errResponse = &dto.ErrorResponse{Status: fiber.StatusConflict,
+				Error: "requested quantity exceeds available stock",
+				Data:  map[string]interface{}{"product_ids": outOfStock}}
+			return errors.New(errResponse.Error)
+		}
 
-	order.UserId = userId
-	order.Name = userDetails.FirstName + " " + userDetails.LastName
-	order.Email = userDetails.Email
-	order.Phone = userDetails.Phone
-	order.Status = constants.Placed
-	order.TotalAmount = totalAmount
-	order.Products = orderItems
+		for _, product := range products {
+			record = tx.Model(&models.Products{}).Where("product_id = ?", product.ProductId).Update("stock", product.Stock)
+			if record.Error != nil {
+				return record.Error
+			}
+		}
 
-	record = db.Create(&order)
-	if record.Error != nil {
+		record = tx.Where("user_id= ?", userId).First(&userDetails)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		order.UserId = userId
+		order.Address = models.ShippingAddress{
+			DoorNo:  addressDetails.DoorNo,
+			Street:  addressDetails.Street,
+			City:    addressDetails.City,
+			State:   addressDetails.State,
+			ZipCode: addressDetails.ZipCode,
+		}
+		order.Name = userDetails.FirstName + " " + userDetails.LastName
+		order.Email = userDetails.Email
+		order.Phone = userDetails.Phone
+		order.Status = constants.Placed
+		order.TotalAmount = totalAmount
+		order.Products = nil
+		order.StatusHistory = []models.OrderStatusHistory{{
+			ToStatus:  constants.Placed,
+			ChangedBy: userId,
+			Role:      constants.UserRole,
+		}}
+
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+
+		for _, merchantId := range merchantIds {
+			shipment := models.Shipments{
+				OrderId:    order.OrderId,
+				MerchantId: &merchantId,
+				Status:     constants.Placed,
+				Items:      shipmentItems[merchantId],
+			}
+			for index := range shipment.Items {
+				shipment.Items[index].OrderId = order.OrderId
+			}
+
+			if err := tx.Create(&shipment).Error; err != nil {
+				return err
+			}
+
+			order.Shipments = append(order.Shipments, shipment)
+			order.Products = append(order.Products, shipment.Items...)
+		}
+
+		if finalize != nil {
+			return finalize(tx)
+		}
+
+		return nil
+	})
+	if errResponse != nil {
+		loggers.WarnLog.Println(errResponse.Error)
+		return nil, errResponse
+	} else if err != nil {
 		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
+			Error: err.Error()}
 	}
 
 	return &order, nil
 }
 
-func (db *userRepository) UpdateUserRepository(user *models.Users) *dto.ErrorResponse {
-	var userExcist models.Users
+func (db *userRepository) GetUserRepository(userId uuid.UUID) (*models.Users, *dto.ErrorResponse) {
+	var user models.Users
 
-	record := db.Where("user_id = ? ", user.UserId).First(&userExcist)
-	if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusNotFound,
+	record := db.Where("user_id = ?", userId).First(&user)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
 			Error: "user not found"}
+	} else if record.Error != nil {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: record.Error.Error()}
 	}
 
-	record = db.Where("user_id = ?", user.UserId).Updates(models.Users{
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Phone:     user.Phone,
-		Password:  user.Password})
-	if record.Error != nil {
-		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	} else if record.RowsAffected == 0 {
+	return &user, nil
+}
+
+func updatePassword(db *gorm.DB, userId uuid.UUID, sessionId uuid.UUID, hashedPassword string) *dto.ErrorResponse {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Model(&models.Users{}).Where("user_id = ?", userId).Update("password", hashedPassword)
+		if record.Error != nil {
+			return record.Error
+		}
+
+		return tx.Model(&models.Sessions{}).Where("user_id = ? AND session_id <> ? AND revoked_at IS NULL", userId, sessionId).Update("revoked_at", time.Now()).Error
+	})
+	if err != nil {
 		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: "something went wrong"}
+			Error: err.Error()}
 	}
 
-	for _, data := range user.Address {
-		record = db.Where("address_id = ?", data.AddressId).Updates(models.Addresses{
-			DoorNo:  data.DoorNo,
-			Street:  data.Street,
-			City:    data.City,
-			State:   data.State,
-			ZipCode: data.ZipCode})
-		if record.Error != nil {
-			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-				Error: record.Error.Error()}
-		} else if record.RowsAffected == 0 {
-			return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-				Error: "something went wrong"}
+	return nil
+}
+
+func (db *userRepository) UpdatePasswordRepository(userId uuid.UUID, sessionId uuid.UUID, hashedPassword string) *dto.ErrorResponse {
+	return updatePassword(db.DB, userId, sessionId, hashedPassword)
+}
+
+func updateProfile(db *gorm.DB, userId uuid.UUID, updates map[string]interface{}, addresses []models.Addresses) *dto.ErrorResponse {
+	var errResponse *dto.ErrorResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(updates) != 0 {
+			record := tx.Model(&models.Users{}).Where("user_id = ?", userId).Updates(updates)
+			if record.Error != nil {
+				return record.Error
+			} else if record.RowsAffected == 0 {
+				errResponse = &dto.ErrorResponse{Status: fiber.StatusNotFound,
+					Error: "user not found"}
+				return errors.New(errResponse.Error)
+			}
+		}
+
+		for _, data := range addresses {
+			record := tx.Model(&models.Addresses{}).Where("address_id = ? AND user_id = ?", data.AddressId, userId).Updates(models.Addresses{
+				DoorNo:  data.DoorNo,
+				Street:  data.Street,
+				City:    data.City,
+				State:   data.State,
+				ZipCode: data.ZipCode})
+			if record.Error != nil {
+				return record.Error
+			} else if record.RowsAffected == 0 {
+				errResponse = &dto.ErrorResponse{Status: fiber.StatusNotFound,
+					Error: "address not found on your profile"}
+				return errors.New(errResponse.Error)
+			}
 		}
+
+		return nil
+	})
+	if errResponse != nil {
+		return errResponse
+	} else if err != nil {
+		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
 	}
 
 	return nil
 }
 
+func (db *userRepository) UpdateUserRepository(userId uuid.UUID, updates map[string]interface{}, addresses []models.Addresses) *dto.ErrorResponse {
+	return updateProfile(db.DB, userId, updates, addresses)
+}
+
 func (db *userRepository) CancelOrderRepository(userId uuid.UUID, orderId uuid.UUID) *dto.ErrorResponse {
 	var order models.Orders
 	record := db.Where("order_id = ? AND user_id= ? ", orderId, userId).First(&order)
@@ -135,19 +289,15 @@ func (db *userRepository) CancelOrderRepository(userId uuid.UUID, orderId uuid.U
 			Error: "order not avilable"}
 	}
 
-	record = db.Model(&order).Where("order_id = ?", orderId).Update("status", constants.Cancelled)
-	if record.RowsAffected == 0 {
-		return &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	}
-
-	return nil
+	return updateOrderStatus(db.DB, orderId, constants.Cancelled, userId, constants.UserRole)
 }
 
 func (db *userRepository) GetOrdersRepository(userId uuid.UUID) (*[]models.Orders, *dto.ErrorResponse) {
 	var orders []models.Orders
 
-	record := db.Preload("Products").Where("user_id= ?", userId).Find(&orders)
+	record := db.Preload("Products").Preload("Shipments").Preload("StatusHistory", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("created_at")
+	}).Where("user_id= ?", userId).Find(&orders)
 	if record.Error != nil {
 		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
@@ -156,26 +306,18 @@ func (db *userRepository) GetOrdersRepository(userId uuid.UUID) (*[]models.Order
 	return &orders, nil
 }
 
-func (db *userRepository) GetProductsRepository(filter map[string]string, userId uuid.UUID) (*[]models.Products, *dto.ErrorResponse) {
-	var products []models.Products
-
-	categoryName := filter["category_name"]
-	brandName := filter["brand_name"]
-
-	record := db.Raw(`SELECT * FROM getProductsUser_fn($1,$2)`, brandName, categoryName).Find(&products)
-	if record.Error != nil {
-		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
-	}
-
-	return &products, nil
+func (db *userRepository) GetProductsRepository(filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	return listProducts(db.DB, filter)
 }
 
 func (db *userRepository) GetProductRepository(userId uuid.UUID, productId uuid.UUID) (*models.Products, *dto.ErrorResponse) {
 	var product models.Products
 
-	record := db.Where("product_id = ?", productId).First(&product)
-	if record.Error != nil {
+	record := db.Where("product_id = ? AND is_approved = ?", productId, true).First(&product)
+	if errors.Is(record.Error, gorm.ErrRecordNotFound) {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusNotFound,
+			Error: "product does not exists"}
+	} else if record.Error != nil {
 		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
 			Error: record.Error.Error()}
 	}
@@ -183,23 +325,42 @@ func (db *userRepository) GetProductRepository(userId uuid.UUID, productId uuid.
 	return &product, nil
 }
 
-func (db *userRepository) FilterProductsRepository(filter map[string]string) (*[]models.Products, *dto.ErrorResponse) {
-	var products []models.Products
+func (db *userRepository) SearchProductsRepository(filter dto.ProductSearchFilter, tsQuery string) (*[]dto.ProductSearchResult, int64, *dto.ErrorResponse) {
+	var (
+		results []dto.ProductSearchResult
+		total   int64
+	)
+	args := map[string]interface{}{
+		"tsquery": tsQuery,
+		"query":   filter.Query,
+		"limit":   filter.Limit,
+		"offset":  (filter.Page - 1) * filter.Limit,
+	}
 
-	price := filter["price"]
-	rating := filter["rating"]
-	if price == "" && rating == "" {
-		return nil, &dto.ErrorResponse{
-			Status: fiber.StatusBadRequest,
-			Error:  "filter keywords should not be empty",
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)`, strconv.FormatFloat(constants.SearchSimilarityThreshold, 'f', -1, 64)).Error
+		if err != nil {
+			return err
 		}
-	}
 
-	record := db.Raw(`SELECT * FROM filterProductsUser_fn($1,$2)`, price, rating).Find(&products)
-	if record.Error != nil {
-		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
-			Error: record.Error.Error()}
+		err = tx.Raw(`SELECT COUNT(*) FROM products AS p WHERE `+productSearchCondition, args).Scan(&total).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Raw(`SELECT p.product_id, p.product_name, p.description, p.category_id, p.brand_id, p.user_id, p.price, p.rating, p.review_count, p.stock, p.created_at,
+				ts_rank(p.search_vector, to_tsquery('simple', @tsquery)) + word_similarity(@query, p.product_name) AS relevance,
+				ts_headline('simple', `+fmt.Sprintf(escapeHTML, "p.product_name")+`, to_tsquery('simple', @tsquery), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS highlighted_name,
+				ts_headline('simple', `+fmt.Sprintf(escapeHTML, "coalesce(p.description, '')")+`, to_tsquery('simple', @tsquery), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS highlighted_description
+			FROM products AS p
+			WHERE `+productSearchCondition+`
+			ORDER BY relevance DESC, p.product_id
+			LIMIT @limit OFFSET @offset`, args).Scan(&results).Error
+	})
+	if err != nil {
+		return nil, 0, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
 	}
 
-	return &products, nil
+	return &results, total, nil
 }
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPlaceOrderRepositoryLastUnit(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommenceUserRepository(db)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	customer := createTestUser(t, db, constants.UserRole)
+	product := createTestProduct(t, db, merchant.UserId, models.Products{Price: 100, Stock: 1})
+
+	var (
+		wait    sync.WaitGroup
+		start   = make(chan struct{})
+		results = make([]*dto.ErrorResponse, 2)
+	)
+	for i := range results {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			<-start
+
+			order := models.Orders{
+				AddressId: customer.Address[0].AddressId,
+				Products:  []models.OrderedItems{{ProductId: product.ProductId, Quantity: 1}},
+			}
+			_, results[i] = repo.PlaceOrderRepository(customer.UserId, order)
+		}()
+	}
+	close(start)
+	wait.Wait()
+
+	var succeeded, outOfStock int
+	for _, errResponse := range results {
+		switch {
+		case errResponse == nil:
+			succeeded++
+		case errResponse.Status == fiber.StatusConflict && errResponse.Error == "requested quantity exceeds available stock":
+			outOfStock++
+		default:
+			t.Fatalf("unexpected error: %+v", errResponse)
+		}
+	}
+
+	if succeeded != 1 || outOfStock != 1 {
+		t.Fatalf("expected one success and one out of stock, got %d and %d", succeeded, outOfStock)
+	}
+
+	var stock uint
+	if err := db.Model(&models.Products{}).Select("stock").Where("product_id = ?", product.ProductId).Scan(&stock).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if stock != 0 {
+		t.Fatalf("expected final stock 0, got %d", stock)
+	}
+}
+
+func TestSearchProductsRepositoryEscapesHighlights(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := CommenceUserRepository(db)
+
+	merchant := createTestUser(t, db, constants.MerchantRole)
+	term := "xss" + uniqueSuffix()[:8]
+	product := createTestProduct(t, db, merchant.UserId, models.Products{
+		ProductName: `<script>alert(1)</script> ` + term,
+		Description: `<img src=x onerror="alert(1)"> ` + term,
+		Price:       100,
+	})
+
+	results, _, errResponse := repo.SearchProductsRepository(dto.ProductSearchFilter{Query: term, Page: 1, Limit: 10}, term+":*")
+	if errResponse != nil {
+		t.Fatalf("search products: %s", errResponse.Error)
+	}
+
+	for _, result := range *results {
+		if result.ProductId != product.ProductId {
+			continue
+		}
+
+		if strings.Contains(result.HighlightedName, "<script>") || !strings.Contains(result.HighlightedName, "&lt;script&gt;") {
+			t.Errorf("highlighted name is not escaped: %s", result.HighlightedName)
+		}
+
+		if strings.Contains(result.HighlightedDescription, "<img") || !strings.Contains(result.HighlightedDescription, "<mark>"+term+"</mark>") {
+			t.Errorf("highlighted description is not escaped: %s", result.HighlightedDescription)
+		}
+
+		return
+	}
+
+	t.Fatalf("product %s not found in search results", product.ProductId)
+}
@@ -5,6 +5,9 @@ import (
 	"shopping-site/api/middleware"
 	"shopping-site/api/repositories"
 	"shopping-site/api/services"
+	"shopping-site/pkg/mailer"
+	"shopping-site/pkg/storage"
+	"shopping-site/utils/constants"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,15 +15,75 @@ import (
 
 func AdminRoute(app *fiber.App, db *gorm.DB) {
 	adminRepository := repositories.CommenceAdminRepository(db)
+	authRepository := repositories.CommenceAuthRepository(db)
+	permissionRepository := repositories.CommencePermissionRepository(db)
+	onboardingRepository := repositories.CommenceOnboardingRepository(db)
+	catalogRepository := repositories.CommenceCatalogRepository(db)
+	reviewRepository := repositories.CommenceReviewRepository(db)
 
-	adminService := services.CommenceAdminService(adminRepository)
+	adminService := services.CommenceAdminService(adminRepository, permissionRepository)
+	onboardingService := services.CommenceOnboardingService(onboardingRepository, storage.NewStorage())
+	authService := services.CommenceAuthService(authRepository, mailer.NewMailer())
+	catalogService := services.CommenceCatalogService(catalogRepository)
+	reviewService := services.CommenceReviewService(reviewRepository)
 
-	handler := handlers.AdminHandler{IAdminService: adminService}
+	handler := handlers.AdminHandler{IAdminService: adminService, IAuthService: authService}
+	onboardingHandler := handlers.OnboardingHandler{IOnboardingService: onboardingService}
+	catalogHandler := handlers.CatalogHandler{ICatalogService: catalogService}
+	reviewHandler := handlers.ReviewHandler{IReviewService: reviewService}
 
 	user := app.Group("/v1/role/admin")
-	user.Use(middleware.ValidateJwt, middleware.AdminRoleAuthentication)
+	user.Use(middleware.ValidateJwt(authRepository))
 
-	user.Post("/category", handler.AddCategoreyHandler)
-	user.Post("/brand", handler.AddBrandHandler)
+	catalogManage := middleware.RequirePermission(permissionRepository, constants.CatalogManage)
+	orderManage := middleware.RequirePermission(permissionRepository, constants.OrderManage)
+	productApprove := middleware.RequirePermission(permissionRepository, constants.ProductApprove)
+	securityManage := middleware.RequirePermission(permissionRepository, constants.SecurityManage)
+	permissionManage := middleware.RequirePermission(permissionRepository, constants.PermissionManage)
+	merchantReview := middleware.RequirePermission(permissionRepository, constants.MerchantReview)
+	userManage := middleware.RequirePermission(permissionRepository, constants.UserManage)
+	reviewModerate := middleware.RequirePermission(permissionRepository, constants.ReviewModerate)
 
+	user.Post("/category", catalogManage, catalogHandler.AddCategoryHandler)
+	user.Get("/category", catalogManage, catalogHandler.GetCategoriesHandler)
+	user.Get("/category/:id", catalogManage, catalogHandler.GetCategoryHandler)
+	user.Patch("/category/:id", catalogManage, catalogHandler.UpdateCategoryHandler)
+	user.Delete("/category/:id", catalogManage, catalogHandler.DeleteCategoryHandler)
+	user.Post("/brand", catalogManage, catalogHandler.AddBrandHandler)
+	user.Get("/brand", catalogManage, catalogHandler.GetBrandsHandler)
+	user.Get("/brand/:id", catalogManage, catalogHandler.GetBrandHandler)
+	user.Patch("/brand/:id", catalogManage, catalogHandler.UpdateBrandHandler)
+	user.Delete("/brand/:id", catalogManage, catalogHandler.DeleteBrandHandler)
+	user.Patch("/order/:id", orderManage, handler.UpdateOrderStatusHandler)
+	user.Get("/product", productApprove, handler.GetProductsHandler)
+	user.Get("/product/pending", productApprove, handler.GetPendingProductsHandler)
+	user.Patch("/product/:id/approve", productApprove, handler.ApproveProductHandler)
+	user.Patch("/product/:id/reject", productApprove, handler.RejectProductHandler)
+	user.Get("/2fa-policy", securityManage, handler.GetTwoFactorPoliciesHandler)
+	user.Patch("/2fa-policy", securityManage, handler.UpdateTwoFactorPolicyHandler)
+	user.Patch("/user/:id/unlock", securityManage, handler.UnlockAccountHandler)
+	user.Get("/permission", permissionManage, handler.GetPermissionsHandler)
+	user.Post("/permission/:role", permissionManage, handler.GrantPermissionHandler)
+	user.Delete("/permission/:role/:permission", permissionManage, handler.RevokePermissionHandler)
+	user.Get("/merchant-application", merchantReview, onboardingHandler.GetMerchantApplicationsHandler)
+	user.Get("/merchant-application/:id", merchantReview, onboardingHandler.GetMerchantApplicationHandler)
+	user.Get("/merchant-application/:id/document/:documentId", merchantReview, onboardingHandler.GetMerchantDocumentHandler)
+	user.Patch("/merchant-application/:id/approve", merchantReview, onboardingHandler.ApproveMerchantApplicationHandler)
+	user.Patch("/merchant-application/:id/reject", merchantReview, onboardingHandler.RejectMerchantApplicationHandler)
+	user.Get("/user", userManage, handler.GetUsersHandler)
+	user.Get("/user/:id", userManage, handler.GetUserHandler)
+	user.Get("/user/:id/order", userManage, handler.GetUserOrdersHandler)
+	user.Get("/user/:id/address", userManage, handler.GetUserAddressesHandler)
+	user.Patch("/user/:id/suspend", userManage, handler.SuspendUserHandler)
+	user.Patch("/user/:id/reactivate", userManage, handler.ReactivateUserHandler)
+	user.Patch("/user/:id/role", userManage, handler.ChangeUserRoleHandler)
+	user.Post("/user/:id/password-reset", userManage, handler.ForcePasswordResetHandler)
+	user.Delete("/user/:id", userManage, handler.DeleteUserHandler)
+	user.Get("/review/moderation", reviewModerate, reviewHandler.GetModerationQueueHandler)
+	user.Patch("/review/:id/approve", reviewModerate, reviewHandler.ApproveReviewHandler)
+	user.Patch("/review/:id/hide", reviewModerate, reviewHandler.HideReviewHandler)
+	user.Delete("/review/:id", reviewModerate, reviewHandler.RemoveReviewHandler)
+	user.Get("/banned-word", reviewModerate, reviewHandler.GetBannedWordsHandler)
+	user.Post("/banned-word", reviewModerate, reviewHandler.AddBannedWordHandler)
+	user.Delete("/banned-word/:word", reviewModerate, reviewHandler.DeleteBannedWordHandler)
 }
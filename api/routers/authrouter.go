@@ -2,8 +2,10 @@ package routers
 
 import (
 	"shopping-site/api/handlers"
+	"shopping-site/api/middleware"
 	"shopping-site/api/repositories"
 	"shopping-site/api/services"
+	"shopping-site/pkg/mailer"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,10 +14,21 @@ import (
 func AuthRoute(app *fiber.App, db *gorm.DB) {
 	authRepository := repositories.CommenceAuthRepository(db)
 
-	authService := services.CommenceAuthService(authRepository)
+	authService := services.CommenceAuthService(authRepository, mailer.NewMailer())
 
 	handler := handlers.AuthHandler{IAuthService: authService}
 
 	app.Post("/signup", handler.SignupHandler)
 	app.Post("/login", handler.LoginHandler)
+	app.Post("/login/2fa", handler.TwoFactorLoginHandler)
+	app.Get("/verify-email", handler.VerifyEmailHandler)
+	app.Post("/verify-email/resend", handler.ResendVerificationHandler)
+	app.Post("/password/forgot", handler.ForgotPasswordHandler)
+	app.Post("/password/reset", handler.ResetPasswordHandler)
+	app.Post("/refresh", middleware.ValidateCsrf, handler.RefreshHandler)
+	app.Post("/logout", middleware.ValidateJwt(authRepository), handler.LogoutHandler)
+	app.Post("/logout-all", middleware.ValidateJwt(authRepository), handler.LogoutAllHandler)
+	app.Post("/2fa/enroll", middleware.ValidateJwtForEnrollment(authRepository), handler.EnrollTwoFactorHandler)
+	app.Post("/2fa/confirm", middleware.ValidateJwtForEnrollment(authRepository), handler.ConfirmTwoFactorHandler)
+	app.Post("/2fa/disable", middleware.ValidateJwt(authRepository), handler.DisableTwoFactorHandler)
 }
@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"shopping-site/api/handlers"
+	"shopping-site/api/repositories"
+	"shopping-site/api/services"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func CatalogRoute(app *fiber.App, db *gorm.DB) {
+	catalogRepository := repositories.CommenceCatalogRepository(db)
+
+	catalogService := services.CommenceCatalogService(catalogRepository)
+
+	handler := handlers.CatalogHandler{ICatalogService: catalogService}
+
+	catalog := app.Group("/v1")
+
+	catalog.Get("/category", handler.GetCategoryTreeHandler)
+	catalog.Get("/category/:slug", handler.GetCategoryTreeHandler)
+	catalog.Get("/brand", handler.GetBrandsHandler)
+}
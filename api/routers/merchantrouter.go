@@ -5,6 +5,8 @@ import (
 	"shopping-site/api/middleware"
 	"shopping-site/api/repositories"
 	"shopping-site/api/services"
+	"shopping-site/pkg/mailer"
+	"shopping-site/utils/constants"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,20 +14,35 @@ import (
 
 func MerchantRoute(app *fiber.App, db *gorm.DB) {
 	merchantRepository := repositories.CommenceMerchantRepository(db)
+	authRepository := repositories.CommenceAuthRepository(db)
+	permissionRepository := repositories.CommencePermissionRepository(db)
+	reviewRepository := repositories.CommenceReviewRepository(db)
 
-	merchantService := services.CommenceMerchantService(merchantRepository)
+	merchantService := services.CommenceMerchantService(merchantRepository, mailer.NewMailer())
+	reviewService := services.CommenceReviewService(reviewRepository)
 
 	handler := handlers.MerchantHandler{IMerchantService: merchantService}
+	reviewHandler := handlers.ReviewHandler{IReviewService: reviewService}
 
 	merchant := app.Group("/v1/role/merchant")
-	merchant.Use(middleware.ValidateJwt, middleware.MerchantRoleAuthentication)
-
-	merchant.Post("/product", handler.AddProductHandler)
-	merchant.Get("product", handler.GetProductsHandler)
-	merchant.Get("/order", handler.GetOrdersHandler)
-	merchant.Get("/product/:id", handler.GetProductHandler)
-	merchant.Patch("/product", handler.UpdateProductHandler)
-	merchant.Patch("", handler.UpdateMerchantHandler)
-	merchant.Patch("/order:id", handler.UpdateOrderStatusHandler)
-	merchant.Delete("/product/:id", handler.RemoveProductHandler)
+	merchant.Use(middleware.ValidateJwt(authRepository))
+
+	productWrite := middleware.RequirePermission(permissionRepository, constants.ProductWrite)
+	orderShip := middleware.RequirePermission(permissionRepository, constants.OrderShip)
+	profileManage := middleware.RequirePermission(permissionRepository, constants.ProfileManage)
+	reviewReply := middleware.RequirePermission(permissionRepository, constants.ReviewReply)
+
+	merchant.Post("/product", productWrite, handler.AddProductHandler)
+	merchant.Get("product", productWrite, handler.GetProductsHandler)
+	merchant.Get("/order", orderShip, handler.GetOrdersHandler)
+	merchant.Get("/product/:id", productWrite, handler.GetProductHandler)
+	merchant.Patch("/product", productWrite, handler.UpdateProductHandler)
+	merchant.Patch("/product/:id/stock", productWrite, handler.UpdateStockHandler)
+	merchant.Patch("", profileManage, handler.UpdateMerchantHandler)
+	merchant.Patch("/password", profileManage, handler.ChangePasswordHandler)
+	merchant.Patch("/order/:id", orderShip, handler.UpdateOrderStatusHandler)
+	merchant.Delete("/product/:id", productWrite, handler.RemoveProductHandler)
+
+	merchant.Get("/product/:id/review", reviewReply, reviewHandler.GetProductReviewsHandler)
+	merchant.Patch("/review/:id/reply", reviewReply, reviewHandler.ReplyReviewHandler)
 }
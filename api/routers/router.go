@@ -7,6 +7,7 @@ import (
 
 func RequiredRoute(app *fiber.App, db *gorm.DB) {
 	AuthRoute(app, db)
+	CatalogRoute(app, db)
 	AdminRoute(app, db)
 	UserRoute(app, db)
 	MerchantRoute(app, db)
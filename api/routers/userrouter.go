@@ -5,6 +5,9 @@ import (
 	"shopping-site/api/middleware"
 	"shopping-site/api/repositories"
 	"shopping-site/api/services"
+	"shopping-site/pkg/mailer"
+	"shopping-site/pkg/storage"
+	"shopping-site/utils/constants"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -12,19 +15,69 @@ import (
 
 func UserRoute(app *fiber.App, db *gorm.DB) {
 	userRepository := repositories.CommenceUserRepository(db)
+	cartRepository := repositories.CommenceCartRepository(db)
+	authRepository := repositories.CommenceAuthRepository(db)
+	permissionRepository := repositories.CommencePermissionRepository(db)
+	onboardingRepository := repositories.CommenceOnboardingRepository(db)
+	addressRepository := repositories.CommenceAddressRepository(db)
+	reviewRepository := repositories.CommenceReviewRepository(db)
 
-	userService := services.CommenceUserService(userRepository)
+	userService := services.CommenceUserService(userRepository, mailer.NewMailer())
+	cartService := services.CommenceCartService(cartRepository, userRepository)
+	onboardingService := services.CommenceOnboardingService(onboardingRepository, storage.NewStorage())
+	addressService := services.CommenceAddressService(addressRepository)
+	reviewService := services.CommenceReviewService(reviewRepository)
 
 	handler := handlers.UserHandler{IUserService: userService}
+	cartHandler := handlers.CartHandler{ICartService: cartService}
+	onboardingHandler := handlers.OnboardingHandler{IOnboardingService: onboardingService}
+	addressHandler := handlers.AddressHandler{IAddressService: addressService}
+	reviewHandler := handlers.ReviewHandler{IReviewService: reviewService}
 
 	user := app.Group("/v1/role/user")
-	user.Use(middleware.ValidateJwt)
-
-	user.Post("/order", handler.PlaceOrderHandler)
-	user.Get("/order", handler.GetOrdersHandler)
-	user.Get("/product/filter", handler.FilterProductsHandler)
-	user.Get("product", handler.GetProductsHandler)
-	user.Get("/product/:id", handler.GetProductHandler)
-	user.Patch("", handler.UpdateUserHandler)
-	user.Patch("/order/:id", handler.CancelOrderHandler)
+	user.Use(middleware.ValidateJwt(authRepository))
+
+	productRead := middleware.RequirePermission(permissionRepository, constants.ProductRead)
+	cartManage := middleware.RequirePermission(permissionRepository, constants.CartManage)
+	orderPlace := middleware.RequirePermission(permissionRepository, constants.OrderPlace)
+	orderRead := middleware.RequirePermission(permissionRepository, constants.OrderRead)
+	orderCancel := middleware.RequirePermission(permissionRepository, constants.OrderCancel)
+	profileManage := middleware.RequirePermission(permissionRepository, constants.ProfileManage)
+	checkout := middleware.RequirePermission(permissionRepository, constants.CartManage, constants.OrderPlace)
+	merchantApply := middleware.RequirePermission(permissionRepository, constants.MerchantApply)
+	addressManage := middleware.RequirePermission(permissionRepository, constants.AddressManage)
+	reviewWrite := middleware.RequirePermission(permissionRepository, constants.ReviewWrite)
+	reviewReport := middleware.RequirePermission(permissionRepository, constants.ReviewReport)
+
+	user.Post("/order", orderPlace, handler.PlaceOrderHandler)
+	user.Get("/order", orderRead, handler.GetOrdersHandler)
+	user.Get("/product/filter", productRead, handler.FilterProductsHandler)
+	user.Get("/product/search", productRead, handler.SearchProductsHandler)
+	user.Get("product", productRead, handler.GetProductsHandler)
+	user.Get("/product/:id", productRead, handler.GetProductHandler)
+	user.Patch("", profileManage, handler.UpdateUserHandler)
+	user.Patch("/password", profileManage, handler.ChangePasswordHandler)
+	user.Patch("/order/:id", orderCancel, handler.CancelOrderHandler)
+
+	user.Get("/cart", cartManage, cartHandler.GetCartHandler)
+	user.Post("/cart", cartManage, cartHandler.AddCartItemHandler)
+	user.Post("/cart/checkout", checkout, cartHandler.CheckoutHandler)
+	user.Patch("/cart/:id", cartManage, cartHandler.UpdateCartItemHandler)
+	user.Delete("/cart/:id", cartManage, cartHandler.RemoveCartItemHandler)
+	user.Delete("/cart", cartManage, cartHandler.ClearCartHandler)
+
+	user.Post("/merchant-application", merchantApply, onboardingHandler.ApplyMerchantHandler)
+	user.Get("/merchant-application", merchantApply, onboardingHandler.GetUserApplicationsHandler)
+
+	user.Get("/address", addressManage, addressHandler.GetAddressesHandler)
+	user.Get("/address/:id", addressManage, addressHandler.GetAddressHandler)
+	user.Post("/address", addressManage, addressHandler.AddAddressHandler)
+	user.Patch("/address/:id", addressManage, addressHandler.UpdateAddressHandler)
+	user.Delete("/address/:id", addressManage, addressHandler.DeleteAddressHandler)
+
+	user.Get("/product/:id/review", productRead, reviewHandler.GetProductReviewsHandler)
+	user.Post("/product/:id/review", reviewWrite, reviewHandler.AddReviewHandler)
+	user.Patch("/review/:id", reviewWrite, reviewHandler.UpdateReviewHandler)
+	user.Delete("/review/:id", reviewWrite, reviewHandler.DeleteReviewHandler)
+	user.Post("/review/:id/report", reviewReport, reviewHandler.ReportReviewHandler)
 }
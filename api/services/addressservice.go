@@ -0,0 +1,157 @@
+package services
+
+import (
+	"shopping-site/api/repositories"
+	"shopping-site/api/validation"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+	"strings"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
+)
+
+type IAddressService interface {
+	GetAddressesService(uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse)
+	GetAddressService(uuid.UUID, string) (*models.Addresses, *dto.ErrorResponse)
+	AddAddressService(uuid.UUID, *models.Addresses) *dto.ErrorResponse
+	UpdateAddressService(uuid.UUID, string, dto.AddressUpdateRequest) (*models.Addresses, *dto.ErrorResponse)
+	DeleteAddressService(uuid.UUID, string) *dto.ErrorResponse
+}
+
+type addressService struct {
+	repositories.IAddressRepository
+}
+
+func CommenceAddressService(address repositories.IAddressRepository) IAddressService {
+	return &addressService{address}
+}
+
+func prepareAddress(address *models.Addresses) *dto.ErrorResponse {
+	if err := validation.ValidateAddress(*address); err != nil {
+		loggers.WarnLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	address.DoorNo = strings.TrimSpace(address.DoorNo)
+	address.Street = strings.TrimSpace(address.Street)
+	address.City = strings.TrimSpace(address.City)
+	address.State = strings.TrimSpace(address.State)
+	return nil
+}
+
+func (repo *addressService) GetAddressesService(userIdCtx uuid.UUID) (*[]models.Addresses, *dto.ErrorResponse) {
+	return repo.GetAddressesRepository(userIdCtx)
+}
+
+func (repo *addressService) GetAddressService(userIdCtx uuid.UUID, id string) (*models.Addresses, *dto.ErrorResponse) {
+	addressId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.GetAddressRepository(userIdCtx, addressId)
+}
+
+func (repo *addressService) AddAddressService(userIdCtx uuid.UUID, address *models.Addresses) *dto.ErrorResponse {
+	if errResponse := prepareAddress(address); errResponse != nil {
+		return errResponse
+	}
+
+	address.UserId = userIdCtx
+	return repo.AddAddressRepository(address)
+}
+
+func applyAddressUpdate(address *models.Addresses, request dto.AddressUpdateRequest) (map[string]interface{}, *dto.ErrorResponse) {
+	updates := map[string]interface{}{}
+
+	if request.DoorNo != nil {
+		address.DoorNo = *request.DoorNo
+	}
+
+	if request.Street != nil {
+		address.Street = *request.Street
+	}
+
+	if request.City != nil {
+		address.City = *request.City
+	}
+
+	if request.State != nil {
+		address.State = *request.State
+	}
+
+	if request.ZipCode != nil {
+		address.ZipCode = *request.ZipCode
+	}
+
+	if request.DoorNo != nil || request.Street != nil || request.City != nil || request.State != nil || request.ZipCode != nil {
+		if errResponse := prepareAddress(address); errResponse != nil {
+			return nil, errResponse
+		}
+
+		updates["door_no"] = address.DoorNo
+		updates["street"] = address.Street
+		updates["city"] = address.City
+		updates["state"] = address.State
+		updates["zip_code"] = address.ZipCode
+	}
+
+	if request.IsDefaultShipping != nil {
+		address.IsDefaultShipping = *request.IsDefaultShipping
+		updates["is_default_shipping"] = address.IsDefaultShipping
+	}
+
+	if request.IsDefaultBilling != nil {
+		address.IsDefaultBilling = *request.IsDefaultBilling
+		updates["is_default_billing"] = address.IsDefaultBilling
+	}
+
+	if len(updates) == 0 {
+		loggers.WarnLog.Println("no address fields to update")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "no address fields to update"}
+	}
+
+	return updates, nil
+}
+
+func (repo *addressService) UpdateAddressService(userIdCtx uuid.UUID, id string, request dto.AddressUpdateRequest) (*models.Addresses, *dto.ErrorResponse) {
+	addressId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	address, errResponse := repo.GetAddressRepository(userIdCtx, addressId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	updates, errResponse := applyAddressUpdate(address, request)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if errResponse := repo.UpdateAddressRepository(address, updates); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return address, nil
+}
+
+func (repo *addressService) DeleteAddressService(userIdCtx uuid.UUID, id string) *dto.ErrorResponse {
+	addressId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.DeleteAddressRepository(userIdCtx, addressId)
+}
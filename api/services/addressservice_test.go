@@ -0,0 +1,40 @@
+package services
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+	"testing"
+)
+
+func TestApplyAddressUpdateKeepsAbsentFields(t *testing.T) {
+	address := models.Addresses{DoorNo: "1", Street: "Main Street", City: "Chennai", State: "Tamil Nadu", ZipCode: 600001, IsDefaultShipping: true, IsDefaultBilling: true}
+	city := "Madurai"
+
+	updates, errResponse := applyAddressUpdate(&address, dto.AddressUpdateRequest{City: &city})
+	if errResponse != nil {
+		t.Fatalf("update city: %s", errResponse.Error)
+	}
+
+	if _, ok := updates["is_default_shipping"]; ok {
+		t.Fatalf("updates = %v, default flags were written without being sent", updates)
+	}
+
+	if updates["city"] != city || updates["street"] != "Main Street" || !address.IsDefaultShipping || !address.IsDefaultBilling {
+		t.Fatalf("updates = %v, address = %+v", updates, address)
+	}
+
+	billing := false
+	updates, errResponse = applyAddressUpdate(&address, dto.AddressUpdateRequest{IsDefaultBilling: &billing})
+	if errResponse != nil || len(updates) != 1 || updates["is_default_billing"] != false {
+		t.Fatalf("billing flag update = (%v, %v), want only is_default_billing", updates, errResponse)
+	}
+
+	zipCode := uint(12)
+	if _, errResponse := applyAddressUpdate(&address, dto.AddressUpdateRequest{ZipCode: &zipCode}); errResponse == nil {
+		t.Fatal("invalid zip code was accepted")
+	}
+
+	if _, errResponse := applyAddressUpdate(&address, dto.AddressUpdateRequest{}); errResponse == nil {
+		t.Fatal("empty update was accepted")
+	}
+}
@@ -2,27 +2,338 @@ package services
 
 import (
 	"shopping-site/api/repositories"
+	"shopping-site/api/validation"
+	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"time"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
 )
 
 type IAdminService interface {
-	AddCategoreyService(*models.Categories) *dto.ErrorResponse
-	AddBrandService(*models.Brands) *dto.ErrorResponse
+	UpdateOrderStatusService(uuid.UUID, string, string) *dto.ErrorResponse
+	GetPendingProductsService() (*[]models.Products, *dto.ErrorResponse)
+	GetProductsService(dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
+	ApproveProductService(string) *dto.ErrorResponse
+	RejectProductService(string, dto.RejectRequest) *dto.ErrorResponse
+	GetTwoFactorPoliciesService() (*[]models.TwoFactorPolicies, *dto.ErrorResponse)
+	UpdateTwoFactorPolicyService(dto.TwoFactorPolicyRequest) (*models.TwoFactorPolicies, *dto.ErrorResponse)
+	UnlockAccountService(string) *dto.ErrorResponse
+	GetPermissionsService() (*dto.PermissionsResponse, *dto.ErrorResponse)
+	GrantPermissionService(string, dto.RolePermissionRequest) *dto.ErrorResponse
+	RevokePermissionService(string, string) *dto.ErrorResponse
+	GetUsersService(dto.UserFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
+	GetUserService(string) (*models.Users, *dto.ErrorResponse)
+	GetUserOrdersService(string) (*[]models.Orders, *dto.ErrorResponse)
+	GetUserAddressesService(string) (*[]models.Addresses, *dto.ErrorResponse)
+	SuspendUserService(uuid.UUID, string, dto.SuspendRequest) *dto.ErrorResponse
+	ReactivateUserService(string) *dto.ErrorResponse
+	ChangeUserRoleService(uuid.UUID, string, dto.RoleRequest) *dto.ErrorResponse
+	DeleteUserService(uuid.UUID, string) *dto.ErrorResponse
 }
 
 type adminService struct {
 	repositories.IAdminRepository
+	repositories.IPermissionRepository
+}
+
+func CommenceAdminService(admin repositories.IAdminRepository, permission repositories.IPermissionRepository) IAdminService {
+	return &adminService{admin, permission}
+}
+
+func (repo *adminService) UpdateOrderStatusService(userIdCtx uuid.UUID, id string, orderStatus string) *dto.ErrorResponse {
+	if err := validation.ValidateOrderStatus(orderStatus); err != nil {
+		loggers.WarnLog.Println(err.Error())
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  err.Error()}
+	}
+
+	orderId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.UpdateOrderStatusRepository(orderId, userIdCtx, orderStatus)
+}
+
+func (repo *adminService) GetPendingProductsService() (*[]models.Products, *dto.ErrorResponse) {
+	return repo.GetPendingProductsRepository()
+}
+
+func (repo *adminService) GetProductsService(filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	if errResponse := prepareProductFilter(&filter); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return repo.GetProductsRepository(filter)
+}
+
+func (repo *adminService) ApproveProductService(id string) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.ReviewProductRepository(productId, constants.Approved, "")
+}
+
+func (repo *adminService) RejectProductService(id string, rejectRequest dto.RejectRequest) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if rejectRequest.Reason == "" {
+		loggers.WarnLog.Println("rejection reason should not be empty")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "rejection reason should not be empty"}
+	}
+
+	return repo.ReviewProductRepository(productId, constants.Rejected, rejectRequest.Reason)
+}
+
+func (repo *adminService) GetTwoFactorPoliciesService() (*[]models.TwoFactorPolicies, *dto.ErrorResponse) {
+	return repo.GetTwoFactorPoliciesRepository()
+}
+
+func (repo *adminService) UpdateTwoFactorPolicyService(policyRequest dto.TwoFactorPolicyRequest) (*models.TwoFactorPolicies, *dto.ErrorResponse) {
+	if errResponse := validateRole(policyRequest.Role); errResponse != nil {
+		return nil, errResponse
+	}
+
+	if policyRequest.Required == nil {
+		loggers.WarnLog.Println("required flag is missing")
+		return nil, &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "required flag is missing"}
+	}
+
+	policy := models.TwoFactorPolicies{Role: policyRequest.Role, Required: *policyRequest.Required}
+	if errResponse := repo.UpdateTwoFactorPolicyRepository(&policy); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return &policy, nil
+}
+
+func (repo *adminService) UnlockAccountService(id string) *dto.ErrorResponse {
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.UnlockAccountRepository(userId)
+}
+
+func validateRole(role string) *dto.ErrorResponse {
+	if role != constants.UserRole && role != constants.MerchantRole && role != constants.AdminRole {
+		loggers.WarnLog.Println("invalid role")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "role should be one of user, merchant or admin"}
+	}
+
+	return nil
+}
+
+func (repo *adminService) GetPermissionsService() (*dto.PermissionsResponse, *dto.ErrorResponse) {
+	permissions, errResponse := repo.GetPermissionsRepository()
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	rolePermissions, errResponse := repo.GetRolePermissionsRepository()
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	permissionsResponse := dto.PermissionsResponse{
+		Permissions: permissions,
+		Roles: map[string][]string{
+			constants.UserRole:     {},
+			constants.MerchantRole: {},
+			constants.AdminRole:    {},
+		},
+	}
+	for _, rolePermission := range *rolePermissions {
+		permissionsResponse.Roles[rolePermission.Role] = append(permissionsResponse.Roles[rolePermission.Role], rolePermission.PermissionName)
+	}
+
+	return &permissionsResponse, nil
 }
 
-func CommenceAdminService(admin repositories.IAdminRepository) IAdminService {
-	return &adminService{admin}
+func (repo *adminService) GrantPermissionService(role string, permissionRequest dto.RolePermissionRequest) *dto.ErrorResponse {
+	if errResponse := validateRole(role); errResponse != nil {
+		return errResponse
+	}
+
+	if permissionRequest.Permission == "" {
+		loggers.WarnLog.Println("permission should not be empty")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "permission should not be empty"}
+	}
+
+	return repo.GrantPermissionRepository(&models.RolePermissions{Role: role, PermissionName: permissionRequest.Permission})
+}
+
+func (repo *adminService) RevokePermissionService(role string, permission string) *dto.ErrorResponse {
+	if errResponse := validateRole(role); errResponse != nil {
+		return errResponse
+	}
+
+	if role == constants.AdminRole && permission == constants.PermissionManage {
+		loggers.WarnLog.Println("permission management cannot be revoked from admin")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusConflict,
+			Error:  "permission management cannot be revoked from admin"}
+	}
+
+	return repo.RevokePermissionRepository(role, permission)
+}
+
+func parseManagedUserId(adminId uuid.UUID, id string) (uuid.UUID, *dto.ErrorResponse) {
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return uuid.Nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if userId == adminId {
+		loggers.WarnLog.Println("admins cannot manage their own account")
+		return uuid.Nil, &dto.ErrorResponse{Status: fiber.StatusConflict,
+			Error: "admins cannot manage their own account"}
+	}
+
+	return userId, nil
 }
 
-func (repo *adminService) AddCategoreyService(category *models.Categories) *dto.ErrorResponse {
-	return repo.AddCategoreyRepository(category)
+func (repo *adminService) GetUsersService(filter dto.UserFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	if filter.Role != "" {
+		if errResponse := validateRole(filter.Role); errResponse != nil {
+			return nil, errResponse
+		}
+	}
+
+	if filter.Status != "" && filter.Status != constants.ActiveStatus && filter.Status != constants.SuspendedStatus && filter.Status != constants.DeletedStatus {
+		loggers.WarnLog.Println("invalid account status")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "status should be one of active, suspended or deleted"}
+	}
+
+	paginate(&filter.Page, &filter.Limit)
+
+	users, total, errResponse := repo.GetUsersRepository(filter)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	for i := range *users {
+		(*users)[i].Password = ""
+	}
+
+	return &dto.PaginatedResponse{Items: users, Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+func (repo *adminService) GetUserService(id string) (*models.Users, *dto.ErrorResponse) {
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	user, errResponse := repo.GetUserRepository(userId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	user.Password = ""
+	return user, nil
 }
 
-func (repo *adminService) AddBrandService(brand *models.Brands) *dto.ErrorResponse {
-	return repo.AddBrandRepository(brand)
+func (repo *adminService) GetUserOrdersService(id string) (*[]models.Orders, *dto.ErrorResponse) {
+	user, errResponse := repo.GetUserService(id)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return repo.GetUserOrdersRepository(user.UserId)
+}
+
+func (repo *adminService) GetUserAddressesService(id string) (*[]models.Addresses, *dto.ErrorResponse) {
+	user, errResponse := repo.GetUserService(id)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return repo.GetUserAddressesRepository(user.UserId)
+}
+
+func (repo *adminService) SuspendUserService(userIdCtx uuid.UUID, id string, suspendRequest dto.SuspendRequest) *dto.ErrorResponse {
+	userId, errResponse := parseManagedUserId(userIdCtx, id)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if suspendRequest.Reason == "" {
+		loggers.WarnLog.Println("suspension reason should not be empty")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "suspension reason should not be empty"}
+	}
+
+	return repo.UpdateUserAccountRepository(userId, map[string]interface{}{
+		"suspended_at":      time.Now(),
+		"suspension_reason": suspendRequest.Reason,
+	})
+}
+
+func (repo *adminService) ReactivateUserService(id string) *dto.ErrorResponse {
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.UpdateUserAccountRepository(userId, map[string]interface{}{
+		"suspended_at":      nil,
+		"suspension_reason": "",
+	})
+}
+
+func (repo *adminService) ChangeUserRoleService(userIdCtx uuid.UUID, id string, roleRequest dto.RoleRequest) *dto.ErrorResponse {
+	userId, errResponse := parseManagedUserId(userIdCtx, id)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if errResponse := validateRole(roleRequest.Role); errResponse != nil {
+		return errResponse
+	}
+
+	return repo.UpdateUserAccountRepository(userId, map[string]interface{}{"role": roleRequest.Role})
+}
+
+func (repo *adminService) DeleteUserService(userIdCtx uuid.UUID, id string) *dto.ErrorResponse {
+	userId, errResponse := parseManagedUserId(userIdCtx, id)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	return repo.DeleteUserRepository(userId)
 }
@@ -1,26 +1,58 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
 	"net/http"
+	"os"
 	"shopping-site/api/repositories"
+	"shopping-site/api/validation"
 	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/mailer"
 	"shopping-site/pkg/models"
+	"shopping-site/pkg/totp"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"strings"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type IAuthService interface {
 	SignUpService(models.Users) *dto.ErrorResponse
-	LoginService(dto.LoginRequest) (*models.Users, *dto.ErrorResponse)
+	LoginService(dto.LoginRequest, string) (*models.Users, *dto.ErrorResponse)
+	CreateSessionService(*models.Users, bool) (*dto.AuthTokens, *dto.ErrorResponse)
+	CreateTwoFactorChallengeService(*models.Users) (string, *dto.ErrorResponse)
+	VerifyTwoFactorLoginService(dto.TwoFactorLoginRequest, string) (*models.Users, *dto.ErrorResponse)
+	EnrollTwoFactorService(uuid.UUID) (*dto.TwoFactorEnrollment, *dto.ErrorResponse)
+	ConfirmTwoFactorService(uuid.UUID, uuid.UUID, dto.TwoFactorCodeRequest) ([]string, *dto.ErrorResponse)
+	DisableTwoFactorService(uuid.UUID, dto.TwoFactorCodeRequest) *dto.ErrorResponse
+	RefreshService(string) (*dto.AuthTokens, *dto.ErrorResponse)
+	LogoutService(uuid.UUID) *dto.ErrorResponse
+	LogoutAllService(uuid.UUID) *dto.ErrorResponse
+	VerifyEmailService(string) *dto.ErrorResponse
+	ResendVerificationService(dto.EmailRequest) *dto.ErrorResponse
+	ForgotPasswordService(dto.EmailRequest) *dto.ErrorResponse
+	ResetPasswordService(dto.PasswordResetRequest) *dto.ErrorResponse
+	ForcePasswordResetService(string) *dto.ErrorResponse
 }
 
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), 8)
+
 type authService struct {
 	repositories.IAuthRepository
+	mailer.Mailer
 }
 
-func CommenceAuthService(auth repositories.IAuthRepository) IAuthService {
-	return &authService{auth}
+func CommenceAuthService(auth repositories.IAuthRepository, mail mailer.Mailer) IAuthService {
+	return &authService{auth, mail}
 }
 
 func (authRepo *authService) SignUpService(user models.Users) *dto.ErrorResponse {
@@ -31,22 +63,584 @@ func (authRepo *authService) SignUpService(user models.Users) *dto.ErrorResponse
 	}
 
 	user.Password = string(hashedPin)
-	if err := authRepo.IAuthRepository.SignUpUser(user); err != nil {
+	user.Role = constants.UserRole
+	user.IsVerified = false
+	for i := range user.Address {
+		user.Address[i].IsDefaultShipping = i == 0
+		user.Address[i].IsDefaultBilling = i == 0
+	}
+	if err := authRepo.IAuthRepository.SignUpUser(&user); err != nil {
 		return err
 	}
 
+	if err := authRepo.sendVerificationEmail(&user); err != nil {
+		loggers.ErrorLog.Println(err)
+	}
+
+	return nil
+}
+
+func (authRepo *authService) LoginService(loginRequest dto.LoginRequest, ip string) (*models.Users, *dto.ErrorResponse) {
+	accountKey := constants.AccountAttemptPrefix + strings.ToLower(strings.TrimSpace(loginRequest.Email))
+	ipKey := constants.IPAttemptPrefix + ip
+
+	if errResponse := authRepo.checkLoginLock(accountKey, ipKey); errResponse != nil {
+		return nil, errResponse
+	}
+
+	user, errResponse := authRepo.IAuthRepository.LoginUser(loginRequest)
+	if errResponse != nil && errResponse.Status != http.StatusNotFound {
+		return nil, errResponse
+	}
+
+	passwordHash := dummyPasswordHash
+	if user != nil {
+		passwordHash = []byte(user.Password)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(passwordHash, []byte(loginRequest.Password)); err != nil || user == nil {
+		return nil, authRepo.recordLoginFailure(accountKey, ipKey)
+	}
+
+	if !user.TwoFactorEnabled {
+		if errResponse := authRepo.IAuthRepository.ClearLoginFailuresRepository(accountKey); errResponse != nil {
+			loggers.ErrorLog.Println(errResponse.Error)
+		}
+	}
+
+	if errResponse := checkAccountStatus(user); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return user, nil
+}
+
+func checkAccountStatus(user *models.Users) *dto.ErrorResponse {
+	if user.SuspendedAt != nil {
+		return &dto.ErrorResponse{Status: http.StatusForbidden, Error: "account suspended"}
+	}
+
+	if user.MustResetPassword {
+		return &dto.ErrorResponse{Status: http.StatusForbidden, Error: "password reset required, check your email for the reset link"}
+	}
+
+	return nil
+}
+
+func (authRepo *authService) checkLoginLock(attemptKeys ...string) *dto.ErrorResponse {
+	lockedUntil, errResponse := authRepo.IAuthRepository.GetLoginLockRepository(attemptKeys...)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if lockedUntil != nil {
+		return &dto.ErrorResponse{
+			Status: http.StatusTooManyRequests,
+			Error:  "too many failed login attempts, try again later",
+			Data:   map[string]interface{}{"retry_after": int(math.Ceil(time.Until(*lockedUntil).Seconds()))},
+		}
+	}
+
 	return nil
 }
 
-func (authRepo *authService) LoginService(loginRequest dto.LoginRequest) (*models.Users, *dto.ErrorResponse) {
-	user, err := authRepo.IAuthRepository.LoginUser(loginRequest)
+func (authRepo *authService) recordLoginFailure(accountKey string, ipKey string) *dto.ErrorResponse {
+	if errResponse := authRepo.IAuthRepository.RecordLoginFailureRepository(accountKey, constants.AccountAttemptThreshold); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+	}
+
+	if errResponse := authRepo.IAuthRepository.RecordLoginFailureRepository(ipKey, constants.IPAttemptThreshold); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+	}
+
+	return &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid email or password"}
+}
+
+func generateOpaqueToken() (string, string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buffer)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateAccessToken(user *models.Users, sessionId uuid.UUID) (string, time.Time, error) {
+	expiresAt := time.Now().Add(constants.AccessTokenDuration)
+
+	claims := &dto.JWTClaims{
+		UserID:    user.UserId,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionId: sessionId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET_KEY")))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+func issueTokens(user *models.Users, sessionId uuid.UUID, refreshToken string, refreshExpiresAt time.Time) (*dto.AuthTokens, *dto.ErrorResponse) {
+	accessToken, accessExpiresAt, err := generateAccessToken(user, sessionId)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "token generation error"}
+	}
+
+	return &dto.AuthTokens{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func (authRepo *authService) CreateSessionService(user *models.Users, twoFactorVerified bool) (*dto.AuthTokens, *dto.ErrorResponse) {
+	refreshToken, refreshTokenHash, err := generateOpaqueToken()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "token generation error"}
+	}
+	refreshExpiresAt := time.Now().Add(constants.RefreshTokenDuration)
+
+	session, errResponse := authRepo.IAuthRepository.CreateSessionRepository(user.UserId, refreshTokenHash, refreshExpiresAt, twoFactorVerified)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return issueTokens(user, session.SessionId, refreshToken, refreshExpiresAt)
+}
+
+func (authRepo *authService) RefreshService(refreshToken string) (*dto.AuthTokens, *dto.ErrorResponse) {
+	if refreshToken == "" {
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "refresh token required"}
+	}
+
+	newRefreshToken, newRefreshTokenHash, err := generateOpaqueToken()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "token generation error"}
+	}
+	refreshExpiresAt := time.Now().Add(constants.RefreshTokenDuration)
+
+	user, session, errResponse := authRepo.IAuthRepository.RotateRefreshTokenRepository(hashToken(refreshToken), newRefreshTokenHash, refreshExpiresAt)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if errResponse := checkAccountStatus(user); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return issueTokens(user, session.SessionId, newRefreshToken, refreshExpiresAt)
+}
+
+func (authRepo *authService) LogoutService(sessionId uuid.UUID) *dto.ErrorResponse {
+	return authRepo.IAuthRepository.RevokeSessionRepository(sessionId)
+}
+
+func (authRepo *authService) LogoutAllService(userId uuid.UUID) *dto.ErrorResponse {
+	return authRepo.IAuthRepository.RevokeUserSessionsRepository(userId)
+}
+
+func generatePurposeToken(user *models.Users, purpose string, tokenId string, expiresAt time.Time) (string, error) {
+	claims := &dto.PurposeTokenClaims{
+		UserID:  user.UserId,
+		Email:   user.Email,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("SECRET_KEY")))
+}
+
+func parsePurposeToken(tokenString string, purpose string) (*dto.PurposeTokenClaims, error) {
+	claims := &dto.PurposeTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
+		return []byte(os.Getenv("SECRET_KEY")), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginRequest.Password)); err != nil {
-		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "invalid password"}
+	if claims.Purpose != purpose {
+		return nil, fmt.Errorf("unexpected token purpose: %s", claims.Purpose)
+	}
+
+	return claims, nil
+}
+
+func (authRepo *authService) sendVerificationEmail(user *models.Users) error {
+	return sendVerificationEmail(authRepo.Mailer, user)
+}
+
+func sendVerificationEmail(mail mailer.Mailer, user *models.Users) error {
+	token, err := generatePurposeToken(user, constants.EmailVerificationPurpose, "", time.Now().Add(constants.EmailVerificationDuration))
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nPlease verify your email address by opening the link below:\n%s/verify-email?token=%s\n\nThe link expires in %s.",
+		user.FirstName, os.Getenv("APP_BASE_URL"), token, constants.EmailVerificationDuration)
+
+	return mail.Send(user.Email, "Verify your email address", body)
+}
+
+func (authRepo *authService) VerifyEmailService(tokenString string) *dto.ErrorResponse {
+	if tokenString == "" {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "verification token required"}
+	}
+
+	claims, err := parsePurposeToken(tokenString, constants.EmailVerificationPurpose)
+	if err != nil {
+		loggers.WarnLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "invalid or expired verification token"}
+	}
+
+	return authRepo.IAuthRepository.VerifyEmailRepository(claims.UserID, claims.Email)
+}
+
+func (authRepo *authService) ResendVerificationService(emailRequest dto.EmailRequest) *dto.ErrorResponse {
+	if emailRequest.Email == "" {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "email field should not be empty"}
+	}
+
+	user, errResponse := authRepo.IAuthRepository.GetUserByEmailRepository(emailRequest.Email)
+	if errResponse != nil {
+		if errResponse.Status == http.StatusNotFound {
+			return nil
+		}
+		return errResponse
+	}
+
+	if user.IsVerified {
+		return nil
+	}
+
+	if err := authRepo.sendVerificationEmail(user); err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "unable to send verification email"}
+	}
+
+	return nil
+}
+
+func (authRepo *authService) ForgotPasswordService(emailRequest dto.EmailRequest) *dto.ErrorResponse {
+	if emailRequest.Email == "" {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "email field should not be empty"}
+	}
+
+	user, errResponse := authRepo.IAuthRepository.GetUserByEmailRepository(emailRequest.Email)
+	if errResponse != nil {
+		if errResponse.Status == http.StatusNotFound {
+			return nil
+		}
+		return errResponse
+	}
+
+	return authRepo.sendPasswordResetEmail(user)
+}
+
+func (authRepo *authService) ForcePasswordResetService(id string) *dto.ErrorResponse {
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: err.Error()}
+	}
+
+	user, errResponse := authRepo.IAuthRepository.ForcePasswordResetRepository(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	return authRepo.sendPasswordResetEmail(user)
+}
+
+func (authRepo *authService) sendPasswordResetEmail(user *models.Users) *dto.ErrorResponse {
+	resetToken, resetTokenHash, err := generateOpaqueToken()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "token generation error"}
+	}
+
+	errResponse := authRepo.IAuthRepository.CreatePasswordResetRepository(user.UserId, resetTokenHash, time.Now().Add(constants.PasswordResetDuration))
+	if errResponse != nil {
+		return errResponse
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password:\n%s/password/reset?token=%s\n\nThe link expires in %s. If you did not request a reset, you can ignore this email.",
+		user.FirstName, os.Getenv("APP_BASE_URL"), resetToken, constants.PasswordResetDuration)
+
+	if err := authRepo.Mailer.Send(user.Email, "Reset your password", body); err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "unable to send password reset email"}
+	}
+
+	return nil
+}
+
+func (authRepo *authService) ResetPasswordService(resetRequest dto.PasswordResetRequest) *dto.ErrorResponse {
+	if resetRequest.Token == "" {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "reset token required"}
+	}
+
+	if err := validation.ValidatePassword(resetRequest.Password); err != nil {
+		loggers.WarnLog.Println(err)
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: err.Error()}
+	}
+
+	hashedPin, err := bcrypt.GenerateFromPassword([]byte(resetRequest.Password), 8)
+	if err != nil {
+		loggers.ErrorLog.Println("Password hasing error")
+		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "Password hasing error"}
+	}
+
+	return authRepo.IAuthRepository.ResetPasswordRepository(hashToken(resetRequest.Token), string(hashedPin))
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, constants.RecoveryCodeCount)
+	codeHashes := make([]string, 0, constants.RecoveryCodeCount)
+
+	for range constants.RecoveryCodeCount {
+		buffer := make([]byte, 5)
+		if _, err := rand.Read(buffer); err != nil {
+			return nil, nil, err
+		}
+
+		code := hex.EncodeToString(buffer)
+		code = code[:5] + "-" + code[5:]
+
+		codes = append(codes, code)
+		codeHashes = append(codeHashes, hashToken(code))
+	}
+
+	return codes, codeHashes, nil
+}
+
+func openTwoFactorSecret(user *models.Users) (string, *dto.ErrorResponse) {
+	key, err := totp.EncryptionKey()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return "", &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "two-factor secret error"}
+	}
+
+	secret, err := totp.OpenSecret(user.TwoFactorSecret, key)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return "", &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "two-factor secret error"}
+	}
+
+	return secret, nil
+}
+
+func (authRepo *authService) verifyTwoFactorCode(user *models.Users, code string) *dto.ErrorResponse {
+	secret, errResponse := openTwoFactorSecret(user)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid two-factor code"}
+	}
+
+	return authRepo.IAuthRepository.ConsumeTwoFactorStepRepository(user.UserId, step)
+}
+
+func (authRepo *authService) CreateTwoFactorChallengeService(user *models.Users) (string, *dto.ErrorResponse) {
+	expiresAt := time.Now().Add(constants.TwoFactorChallengeDuration)
+
+	challengeId, errResponse := authRepo.IAuthRepository.CreateTwoFactorChallengeRepository(user.UserId, expiresAt)
+	if errResponse != nil {
+		return "", errResponse
+	}
+
+	challengeToken, err := generatePurposeToken(user, constants.TwoFactorChallengePurpose, challengeId.String(), expiresAt)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return "", &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "token generation error"}
+	}
+
+	return challengeToken, nil
+}
+
+func (authRepo *authService) VerifyTwoFactorLoginService(loginRequest dto.TwoFactorLoginRequest, ip string) (*models.Users, *dto.ErrorResponse) {
+	if loginRequest.ChallengeToken == "" || (loginRequest.Code == "" && loginRequest.RecoveryCode == "") {
+		return nil, &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "challenge token and code are required"}
+	}
+
+	claims, err := parsePurposeToken(loginRequest.ChallengeToken, constants.TwoFactorChallengePurpose)
+	if err != nil {
+		loggers.WarnLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid or expired challenge token"}
+	}
+
+	challengeId, err := uuid.Parse(claims.ID)
+	if err != nil {
+		loggers.WarnLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid or expired challenge token"}
+	}
+
+	accountKey := constants.AccountAttemptPrefix + strings.ToLower(strings.TrimSpace(claims.Email))
+	ipKey := constants.IPAttemptPrefix + ip
+
+	if errResponse := authRepo.checkLoginLock(accountKey, ipKey); errResponse != nil {
+		return nil, errResponse
+	}
+
+	if errResponse := authRepo.IAuthRepository.ConsumeTwoFactorChallengeRepository(challengeId, claims.UserID); errResponse != nil {
+		return nil, errResponse
+	}
+
+	user, errResponse := authRepo.IAuthRepository.GetUserByIdRepository(claims.UserID)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid or expired challenge token"}
+	}
+
+	if loginRequest.RecoveryCode != "" {
+		errResponse = authRepo.IAuthRepository.UseRecoveryCodeRepository(user.UserId, hashToken(loginRequest.RecoveryCode))
+	} else {
+		errResponse = authRepo.verifyTwoFactorCode(user, loginRequest.Code)
+	}
+
+	if errResponse != nil {
+		if errResponse.Status == http.StatusUnauthorized {
+			authRepo.recordLoginFailure(accountKey, ipKey)
+		}
+
+		return nil, errResponse
+	}
+
+	if errResponse := authRepo.IAuthRepository.ClearLoginFailuresRepository(accountKey); errResponse != nil {
+		loggers.ErrorLog.Println(errResponse.Error)
+	}
+
+	if errResponse := checkAccountStatus(user); errResponse != nil {
+		return nil, errResponse
 	}
 
 	return user, nil
 }
+
+func (authRepo *authService) EnrollTwoFactorService(userId uuid.UUID) (*dto.TwoFactorEnrollment, *dto.ErrorResponse) {
+	user, errResponse := authRepo.IAuthRepository.GetUserByIdRepository(userId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "secret generation error"}
+	}
+
+	key, err := totp.EncryptionKey()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "secret generation error"}
+	}
+
+	sealed, err := totp.SealSecret(secret, key)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "secret generation error"}
+	}
+
+	if errResponse := authRepo.IAuthRepository.SetTwoFactorSecretRepository(userId, sealed); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return &dto.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(constants.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+func (authRepo *authService) ConfirmTwoFactorService(userId uuid.UUID, sessionId uuid.UUID, codeRequest dto.TwoFactorCodeRequest) ([]string, *dto.ErrorResponse) {
+	user, errResponse := authRepo.IAuthRepository.GetUserByIdRepository(userId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, &dto.ErrorResponse{Status: http.StatusConflict, Error: "two-factor authentication already enabled"}
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "two-factor enrollment not started"}
+	}
+
+	secret, errResponse := openTwoFactorSecret(user)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	step, ok := totp.Validate(secret, codeRequest.Code, time.Now())
+	if !ok {
+		return nil, &dto.ErrorResponse{Status: http.StatusUnauthorized, Error: "invalid two-factor code"}
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "recovery code generation error"}
+	}
+
+	if errResponse := authRepo.IAuthRepository.EnableTwoFactorRepository(userId, sessionId, step, codeHashes); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return codes, nil
+}
+
+func (authRepo *authService) DisableTwoFactorService(userId uuid.UUID, codeRequest dto.TwoFactorCodeRequest) *dto.ErrorResponse {
+	user, errResponse := authRepo.IAuthRepository.GetUserByIdRepository(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if !user.TwoFactorEnabled {
+		return &dto.ErrorResponse{Status: http.StatusBadRequest, Error: "two-factor authentication not enabled"}
+	}
+
+	required, errResponse := authRepo.IAuthRepository.IsTwoFactorRequiredRepository(user.Role)
+	if errResponse != nil {
+		return errResponse
+	} else if required {
+		return &dto.ErrorResponse{Status: http.StatusForbidden, Error: "two-factor authentication is required for your role"}
+	}
+
+	if errResponse := authRepo.verifyTwoFactorCode(user, codeRequest.Code); errResponse != nil {
+		return errResponse
+	}
+
+	return authRepo.IAuthRepository.DisableTwoFactorRepository(userId)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"shopping-site/api/repositories"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
+)
+
+type ICartService interface {
+	GetCartService(uuid.UUID) (*dto.CartResponse, *dto.ErrorResponse)
+	AddCartItemService(uuid.UUID, dto.CartItemRequest) *dto.ErrorResponse
+	UpdateCartItemService(uuid.UUID, string, dto.CartItemRequest) *dto.ErrorResponse
+	RemoveCartItemService(uuid.UUID, string) *dto.ErrorResponse
+	ClearCartService(uuid.UUID) *dto.ErrorResponse
+	CheckoutService(uuid.UUID, dto.CheckoutRequest) (*models.Orders, *dto.ErrorResponse)
+}
+
+type cartService struct {
+	repositories.ICartRepository
+	repositories.IUserRepository
+}
+
+func CommenceCartService(cart repositories.ICartRepository, user repositories.IUserRepository) ICartService {
+	return &cartService{cart, user}
+}
+
+func (repo *cartService) GetCartService(userIdCtx uuid.UUID) (*dto.CartResponse, *dto.ErrorResponse) {
+	cart, errResponse := repo.GetCartRepository(userIdCtx)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	cartResponse := dto.CartResponse{CartId: cart.CartId, Items: []dto.CartItemResponse{}}
+	for _, item := range cart.Items {
+		amount := item.Product.Price * float64(item.Quantity)
+		cartResponse.TotalAmount += amount
+
+		cartResponse.Items = append(cartResponse.Items, dto.CartItemResponse{
+			ProductId:   item.ProductId,
+			ProductName: item.Product.ProductName,
+			Price:       item.Product.Price,
+			Quantity:    item.Quantity,
+			Amount:      amount,
+		})
+	}
+
+	return &cartResponse, nil
+}
+
+func (repo *cartService) AddCartItemService(userIdCtx uuid.UUID, item dto.CartItemRequest) *dto.ErrorResponse {
+	if item.ProductId == uuid.Nil || item.Quantity == 0 {
+		loggers.WarnLog.Println("product id and quantity are required")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "product id and quantity are required"}
+	}
+
+	return repo.AddCartItemRepository(userIdCtx, item)
+}
+
+func (repo *cartService) UpdateCartItemService(userIdCtx uuid.UUID, id string, item dto.CartItemRequest) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if item.Quantity == 0 {
+		loggers.WarnLog.Println("quantity should be greater than zero")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "quantity should be greater than zero"}
+	}
+
+	item.ProductId = productId
+	return repo.UpdateCartItemRepository(userIdCtx, item)
+}
+
+func (repo *cartService) RemoveCartItemService(userIdCtx uuid.UUID, id string) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.RemoveCartItemRepository(userIdCtx, productId)
+}
+
+func (repo *cartService) ClearCartService(userIdCtx uuid.UUID) *dto.ErrorResponse {
+	return repo.ClearCartRepository(userIdCtx)
+}
+
+func (repo *cartService) CheckoutService(userIdCtx uuid.UUID, checkout dto.CheckoutRequest) (*models.Orders, *dto.ErrorResponse) {
+	if checkout.AddressId == uuid.Nil {
+		loggers.WarnLog.Println("address id is required")
+		return nil, &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "address id is required"}
+	}
+
+	if errResponse := requireVerifiedEmail(repo.IUserRepository, userIdCtx); errResponse != nil {
+		return nil, errResponse
+	}
+
+	cart, errResponse := repo.GetCartRepository(userIdCtx)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if len(cart.Items) == 0 {
+		loggers.WarnLog.Println("cart is empty")
+		return nil, &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "cart is empty"}
+	}
+
+	var cartItemIds []uuid.UUID
+	order := models.Orders{AddressId: checkout.AddressId}
+	for _, item := range cart.Items {
+		order.Products = append(order.Products, models.OrderedItems{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+		cartItemIds = append(cartItemIds, item.CartItemId)
+	}
+
+	return repo.CheckoutRepository(userIdCtx, order, cartItemIds)
+}
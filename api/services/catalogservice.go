@@ -0,0 +1,334 @@
+package services
+
+import (
+	"regexp"
+	"shopping-site/api/repositories"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+	"strings"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
+)
+
+type ICatalogService interface {
+	AddCategoryService(*models.Categories) *dto.ErrorResponse
+	GetCategoriesService() (*[]models.Categories, *dto.ErrorResponse)
+	GetCategoryService(string) (*models.Categories, *dto.ErrorResponse)
+	GetCategoryTreeService(string) (*[]models.Categories, *dto.ErrorResponse)
+	UpdateCategoryService(string, dto.CategoryUpdateRequest) (*models.Categories, *dto.ErrorResponse)
+	DeleteCategoryService(string) *dto.ErrorResponse
+	AddBrandService(*models.Brands) *dto.ErrorResponse
+	GetBrandsService() (*[]models.Brands, *dto.ErrorResponse)
+	GetBrandService(string) (*models.Brands, *dto.ErrorResponse)
+	UpdateBrandService(string, dto.BrandUpdateRequest) (*models.Brands, *dto.ErrorResponse)
+	DeleteBrandService(string) *dto.ErrorResponse
+}
+
+type catalogService struct {
+	repositories.ICatalogRepository
+}
+
+func CommenceCatalogService(catalog repositories.ICatalogRepository) ICatalogService {
+	return &catalogService{catalog}
+}
+
+var (
+	slugPattern   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	slugSeparator = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+func normalizeSlug(name string, slug string, id uuid.UUID) (string, *dto.ErrorResponse) {
+	if slug == "" {
+		slug = strings.Trim(slugSeparator.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	}
+
+	if slug == "" {
+		if id == uuid.Nil {
+			id = uuid.New()
+		}
+		slug = id.String()[:8]
+	}
+
+	if !slugPattern.MatchString(slug) {
+		loggers.WarnLog.Println("invalid slug")
+		return "", &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "slug should contain only lowercase letters, digits and hyphens"}
+	}
+
+	return slug, nil
+}
+
+func (repo *catalogService) prepareCategory(category *models.Categories) *dto.ErrorResponse {
+	category.CategoryName = strings.TrimSpace(category.CategoryName)
+	if category.CategoryName == "" {
+		loggers.WarnLog.Println("category name should not be empty")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "category name should not be empty"}
+	}
+
+	slug, errResponse := normalizeSlug(category.CategoryName, category.Slug, category.CategoryId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	category.Slug = slug
+	return nil
+}
+
+func (repo *catalogService) prepareBrand(brand *models.Brands) *dto.ErrorResponse {
+	brand.BrandName = strings.TrimSpace(brand.BrandName)
+	if brand.BrandName == "" {
+		loggers.WarnLog.Println("brand name should not be empty")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "brand name should not be empty"}
+	}
+
+	slug, errResponse := normalizeSlug(brand.BrandName, brand.Slug, brand.BrandId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	brand.Slug = slug
+	return nil
+}
+
+func (repo *catalogService) AddCategoryService(category *models.Categories) *dto.ErrorResponse {
+	if errResponse := repo.prepareCategory(category); errResponse != nil {
+		return errResponse
+	}
+
+	return repo.AddCategoryRepository(category)
+}
+
+func (repo *catalogService) GetCategoriesService() (*[]models.Categories, *dto.ErrorResponse) {
+	return repo.GetCategoriesRepository()
+}
+
+func (repo *catalogService) GetCategoryService(id string) (*models.Categories, *dto.ErrorResponse) {
+	categoryId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.GetCategoryRepository(categoryId)
+}
+
+func buildCategoryTree(children map[uuid.UUID][]models.Categories, parentId uuid.UUID) []models.Categories {
+	nodes := children[parentId]
+	for i := range nodes {
+		nodes[i].Children = buildCategoryTree(children, nodes[i].CategoryId)
+	}
+
+	return nodes
+}
+
+func (repo *catalogService) GetCategoryTreeService(slug string) (*[]models.Categories, *dto.ErrorResponse) {
+	categories, errResponse := repo.GetCategoriesRepository()
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	children := map[uuid.UUID][]models.Categories{}
+	for _, category := range *categories {
+		parentId := uuid.Nil
+		if category.ParentId != nil {
+			parentId = *category.ParentId
+		}
+
+		children[parentId] = append(children[parentId], category)
+	}
+
+	if slug == "" {
+		tree := buildCategoryTree(children, uuid.Nil)
+		return &tree, nil
+	}
+
+	root, errResponse := repo.GetCategoryBySlugRepository(slug)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	root.Children = buildCategoryTree(children, root.CategoryId)
+	return &[]models.Categories{*root}, nil
+}
+
+func applyCategoryUpdate(category *models.Categories, request dto.CategoryUpdateRequest) (map[string]interface{}, *dto.ErrorResponse) {
+	updates := map[string]interface{}{}
+
+	if request.CategoryName != nil {
+		category.CategoryName = strings.TrimSpace(*request.CategoryName)
+		if category.CategoryName == "" {
+			loggers.WarnLog.Println("category name should not be empty")
+			return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: "category name should not be empty"}
+		}
+
+		updates["category_name"] = category.CategoryName
+	}
+
+	if request.Slug != nil {
+		slug, errResponse := normalizeSlug(category.CategoryName, *request.Slug, category.CategoryId)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+
+		category.Slug = slug
+		updates["slug"] = slug
+	}
+
+	if request.ParentId != nil {
+		category.ParentId = nil
+		if *request.ParentId != "" {
+			parentId, err := uuid.Parse(*request.ParentId)
+			if err != nil {
+				loggers.WarnLog.Println(err)
+				return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+					Error: "invalid parent id"}
+			}
+
+			category.ParentId = &parentId
+		}
+
+		updates["parent_id"] = category.ParentId
+	}
+
+	if len(updates) == 0 {
+		loggers.WarnLog.Println("no category fields to update")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "no category fields to update"}
+	}
+
+	return updates, nil
+}
+
+func (repo *catalogService) UpdateCategoryService(id string, request dto.CategoryUpdateRequest) (*models.Categories, *dto.ErrorResponse) {
+	categoryId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	category, errResponse := repo.GetCategoryRepository(categoryId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	updates, errResponse := applyCategoryUpdate(category, request)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if errResponse := repo.UpdateCategoryRepository(category, updates); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return category, nil
+}
+
+func (repo *catalogService) DeleteCategoryService(id string) *dto.ErrorResponse {
+	categoryId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.DeleteCategoryRepository(categoryId)
+}
+
+func (repo *catalogService) AddBrandService(brand *models.Brands) *dto.ErrorResponse {
+	if errResponse := repo.prepareBrand(brand); errResponse != nil {
+		return errResponse
+	}
+
+	return repo.AddBrandRepository(brand)
+}
+
+func (repo *catalogService) GetBrandsService() (*[]models.Brands, *dto.ErrorResponse) {
+	return repo.GetBrandsRepository()
+}
+
+func (repo *catalogService) GetBrandService(id string) (*models.Brands, *dto.ErrorResponse) {
+	brandId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.GetBrandRepository(brandId)
+}
+
+func applyBrandUpdate(brand *models.Brands, request dto.BrandUpdateRequest) (map[string]interface{}, *dto.ErrorResponse) {
+	updates := map[string]interface{}{}
+
+	if request.BrandName != nil {
+		brand.BrandName = strings.TrimSpace(*request.BrandName)
+		if brand.BrandName == "" {
+			loggers.WarnLog.Println("brand name should not be empty")
+			return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: "brand name should not be empty"}
+		}
+
+		updates["brand_name"] = brand.BrandName
+	}
+
+	if request.Slug != nil {
+		slug, errResponse := normalizeSlug(brand.BrandName, *request.Slug, brand.BrandId)
+		if errResponse != nil {
+			return nil, errResponse
+		}
+
+		brand.Slug = slug
+		updates["slug"] = slug
+	}
+
+	if len(updates) == 0 {
+		loggers.WarnLog.Println("no brand fields to update")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "no brand fields to update"}
+	}
+
+	return updates, nil
+}
+
+func (repo *catalogService) UpdateBrandService(id string, request dto.BrandUpdateRequest) (*models.Brands, *dto.ErrorResponse) {
+	brandId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	brand, errResponse := repo.GetBrandRepository(brandId)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	updates, errResponse := applyBrandUpdate(brand, request)
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	if errResponse := repo.UpdateBrandRepository(brand, updates); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return brand, nil
+}
+
+func (repo *catalogService) DeleteBrandService(id string) *dto.ErrorResponse {
+	brandId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.DeleteBrandRepository(brandId)
+}
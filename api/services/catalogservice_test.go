@@ -0,0 +1,79 @@
+package services
+
+import (
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeSlug(t *testing.T) {
+	id := uuid.MustParse("3f2a9c1b-0000-4000-8000-000000000000")
+
+	tests := []struct {
+		name string
+		slug string
+		id   uuid.UUID
+		want string
+	}{
+		{name: "Home & Kitchen", want: "home-kitchen"},
+		{name: "Home & Kitchen", slug: "kitchen", want: "kitchen"},
+		{name: "!!!", id: id, want: "3f2a9c1b"},
+		{name: "家电", id: id, want: "3f2a9c1b"},
+	}
+
+	for _, test := range tests {
+		slug, errResponse := normalizeSlug(test.name, test.slug, test.id)
+		if errResponse != nil {
+			t.Fatalf("normalizeSlug(%q, %q): %s", test.name, test.slug, errResponse.Error)
+		}
+
+		if slug != test.want {
+			t.Errorf("normalizeSlug(%q, %q) = %q, want %q", test.name, test.slug, slug, test.want)
+		}
+	}
+
+	slug, errResponse := normalizeSlug("***", "", uuid.Nil)
+	if errResponse != nil || !slugPattern.MatchString(slug) || len(slug) != 8 {
+		t.Fatalf("normalizeSlug without an id = (%q, %v), want a short generated slug", slug, errResponse)
+	}
+}
+
+func TestApplyCategoryUpdateKeepsAbsentFields(t *testing.T) {
+	parentId := uuid.New()
+	category := models.Categories{CategoryId: uuid.New(), CategoryName: "Kitchen", Slug: "kitchen", ParentId: &parentId}
+	name := "Kitchenware"
+
+	updates, errResponse := applyCategoryUpdate(&category, dto.CategoryUpdateRequest{CategoryName: &name})
+	if errResponse != nil {
+		t.Fatalf("rename: %s", errResponse.Error)
+	}
+
+	if len(updates) != 1 || updates["category_name"] != name {
+		t.Fatalf("updates = %v, want only the category name", updates)
+	}
+
+	if category.ParentId == nil || *category.ParentId != parentId || category.Slug != "kitchen" {
+		t.Fatalf("rename moved or re-slugged the category: parent=%v slug=%q", category.ParentId, category.Slug)
+	}
+
+	root := ""
+	updates, errResponse = applyCategoryUpdate(&category, dto.CategoryUpdateRequest{ParentId: &root})
+	if errResponse != nil {
+		t.Fatalf("move to root: %s", errResponse.Error)
+	}
+
+	if parent, ok := updates["parent_id"]; !ok || parent.(*uuid.UUID) != nil || category.ParentId != nil {
+		t.Fatalf("empty parent id did not move the category to the root: %v", updates)
+	}
+
+	if _, errResponse := applyCategoryUpdate(&category, dto.CategoryUpdateRequest{}); errResponse == nil {
+		t.Fatal("empty update was accepted")
+	}
+
+	blank := " "
+	if _, errResponse := applyCategoryUpdate(&category, dto.CategoryUpdateRequest{CategoryName: &blank}); errResponse == nil {
+		t.Fatal("blank category name was accepted")
+	}
+}
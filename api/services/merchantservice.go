@@ -1,40 +1,48 @@
 package services
 
 import (
-	"net/http"
 	"shopping-site/api/repositories"
 	"shopping-site/api/validation"
 	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/mailer"
 	"shopping-site/pkg/models"
 	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"time"
 
 	"github.com/gofiber/fiber"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type IMerchantService interface {
 	AddProductService(uuid.UUID, *models.Products) *dto.ErrorResponse
 	RemoveProductService(string) *dto.ErrorResponse
 	UpdateProductService(uuid.UUID, *models.Products) *dto.ErrorResponse
-	UpdateMerchantService(uuid.UUID, *models.Users) *dto.ErrorResponse
-	GetProductsService(map[string]string, uuid.UUID) (*[]models.Products, *dto.ErrorResponse)
-	UpdateOrderStatusService(uuid.UUID, string, string) *dto.ErrorResponse
+	UpdateStockService(uuid.UUID, string, dto.StockRequest) *dto.ErrorResponse
+	UpdateMerchantService(uuid.UUID, dto.ProfileUpdateRequest) *dto.ErrorResponse
+	ChangePasswordService(uuid.UUID, uuid.UUID, dto.ChangePasswordRequest) *dto.ErrorResponse
+	GetProductsService(uuid.UUID, dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
+	UpdateOrderStatusService(uuid.UUID, string, dto.ShipmentUpdate) *dto.ErrorResponse
 	GetProductService(uuid.UUID, string) (*models.Products, *dto.ErrorResponse)
-	GetOrdersService(uuid.UUID) (*models.Orders, *dto.ErrorResponse)
+	GetOrdersService(uuid.UUID, map[string]string) (*[]models.Orders, *dto.ErrorResponse)
 }
 
 type merchantService struct {
 	repositories.IMerchantRepository
+	mailer.Mailer
 }
 
-func CommenceMerchantService(merchant repositories.IMerchantRepository) IMerchantService {
-	return &merchantService{merchant}
+func CommenceMerchantService(merchant repositories.IMerchantRepository, mail mailer.Mailer) IMerchantService {
+	return &merchantService{merchant, mail}
 }
 
 func (repo *merchantService) AddProductService(userIdCtx uuid.UUID, product *models.Products) *dto.ErrorResponse {
 	product.UserId = userIdCtx
+	product.IsApproved = false
+	product.ApprovalStatus = constants.Pending
+	product.RejectionReason = ""
+	product.Rating = 0
+	product.ReviewCount = 0
 
 	return repo.AddProductRepository(product)
 }
@@ -62,17 +70,26 @@ func (repo *merchantService) UpdateProductService(userIdCtx uuid.UUID, product *
 	return repo.UpdateProductRepository(product)
 }
 
-func (repo *merchantService) UpdateMerchantService(userIdCtx uuid.UUID, user *models.Users) *dto.ErrorResponse {
-	user.UserId = userIdCtx
+func (repo *merchantService) UpdateStockService(userIdCtx uuid.UUID, id string, stockRequest dto.StockRequest) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
 
-	if user.FirstName == "" || user.LastName == "" || user.Email == "" || user.Phone == "" || user.Password == "" {
-		loggers.WarnLog.Println("Required fields should not be empty")
+	if stockRequest.Stock == nil {
+		loggers.WarnLog.Println("stock field should not be empty")
 		return &dto.ErrorResponse{
 			Status: fiber.StatusBadRequest,
-			Error:  "Required fields should not be empty"}
+			Error:  "stock field should not be empty"}
 	}
 
-	if err := validation.ValidateUser(*user); err != nil {
+	return repo.UpdateStockRepository(userIdCtx, productId, *stockRequest.Stock)
+}
+
+func (repo *merchantService) UpdateMerchantService(userIdCtx uuid.UUID, request dto.ProfileUpdateRequest) *dto.ErrorResponse {
+	if err := validation.ValidateProfileUpdate(request); err != nil {
 		loggers.WarnLog.Println(err.Error())
 		return &dto.ErrorResponse{
 			Status: fiber.StatusBadRequest,
@@ -80,34 +97,45 @@ func (repo *merchantService) UpdateMerchantService(userIdCtx uuid.UUID, user *mo
 		}
 	}
 
-	hashedPin, err := bcrypt.GenerateFromPassword([]byte(user.Password), 8)
-	if err != nil {
-		loggers.ErrorLog.Println("Password hasing error")
-		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "Password hasing error"}
+	user, errResponse := repo.GetMerchantRepository(userIdCtx)
+	if errResponse != nil {
+		return errResponse
 	}
 
-	user.Password = string(hashedPin)
-
-	if len(user.Address) != 0 {
+	updates, emailChanged := applyProfileUpdate(user, request)
+	if errResponse := repo.UpdateMerchantRepository(userIdCtx, updates, request.Address); errResponse != nil {
+		return errResponse
+	}
 
-		for _, data := range user.Address {
-			if data.AddressId == uuid.Nil || data.DoorNo == "" || data.Street == "" || data.City == "" || data.State == "" || data.ZipCode == 0 {
-				loggers.WarnLog.Println("Required Address fields should not be empty")
-				return &dto.ErrorResponse{
-					Status: fiber.StatusBadRequest,
-					Error:  "Required Address fields should not be empty"}
-			}
+	if emailChanged {
+		if err := sendVerificationEmail(repo.Mailer, user); err != nil {
+			loggers.ErrorLog.Println(err)
 		}
 	}
-	return repo.UpdateMerchantRepository(user)
+
+	return nil
 }
 
-func (repo *merchantService) UpdateOrderStatusService(userIdCtx uuid.UUID, id string, orderStatus string) *dto.ErrorResponse {
-	if orderStatus != constants.Shipped {
-		loggers.WarnLog.Println("insufficient permission to update specific status")
+func (repo *merchantService) ChangePasswordService(userIdCtx uuid.UUID, sessionIdCtx uuid.UUID, passwordRequest dto.ChangePasswordRequest) *dto.ErrorResponse {
+	user, errResponse := repo.GetMerchantRepository(userIdCtx)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	hashedPassword, errResponse := hashNewPassword(user, passwordRequest)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	return repo.UpdatePasswordRepository(userIdCtx, sessionIdCtx, hashedPassword)
+}
+
+func (repo *merchantService) UpdateOrderStatusService(userIdCtx uuid.UUID, id string, update dto.ShipmentUpdate) *dto.ErrorResponse {
+	if err := validation.ValidateOrderStatus(update.Status); err != nil {
+		loggers.WarnLog.Println(err.Error())
 		return &dto.ErrorResponse{
-			Status: fiber.StatusForbidden,
-			Error:  "insufficient permission to update specific status"}
+			Status: fiber.StatusBadRequest,
+			Error:  err.Error()}
 	}
 
 	userId := userIdCtx
@@ -118,13 +146,17 @@ func (repo *merchantService) UpdateOrderStatusService(userIdCtx uuid.UUID, id st
 			Error: err.Error()}
 	}
 
-	return repo.UpdateOrderStatusRepository(orderId, userId, orderStatus)
+	return repo.UpdateOrderStatusRepository(orderId, userId, update)
 }
 
-func (repo *merchantService) GetProductsService(filters map[string]string, userIdCtx uuid.UUID) (*[]models.Products, *dto.ErrorResponse) {
-	UserId := userIdCtx
+func (repo *merchantService) GetProductsService(userIdCtx uuid.UUID, filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	filter.Merchant = ""
+	if errResponse := prepareProductFilter(&filter); errResponse != nil {
+		return nil, errResponse
+	}
 
-	return repo.GetProductsRepository(filters, UserId)
+	filter.MerchantId = userIdCtx
+	return repo.GetProductsRepository(filter)
 }
 
 func (repo *merchantService) GetProductService(userIdCtx uuid.UUID, id string) (*models.Products, *dto.ErrorResponse) {
@@ -140,8 +172,38 @@ func (repo *merchantService) GetProductService(userIdCtx uuid.UUID, id string) (
 	return repo.GetProductRepository(UserId, productId)
 }
 
-func (repo *merchantService) GetOrdersService(userIdCtx uuid.UUID) (*models.Orders, *dto.ErrorResponse) {
+func (repo *merchantService) GetOrdersService(userIdCtx uuid.UUID, filters map[string]string) (*[]models.Orders, *dto.ErrorResponse) {
 	UserId := userIdCtx
+	filter := dto.OrderFilter{Status: filters["status"]}
+
+	if filter.Status != "" && filter.Status != constants.Placed {
+		if err := validation.ValidateOrderStatus(filter.Status); err != nil {
+			loggers.WarnLog.Println(err.Error())
+			return nil, &dto.ErrorResponse{
+				Status: fiber.StatusBadRequest,
+				Error:  err.Error()}
+		}
+	}
+
+	if from := filters["from"]; from != "" {
+		date, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			loggers.WarnLog.Println(err)
+			return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: "from date should be in YYYY-MM-DD format"}
+		}
+		filter.From = date
+	}
+
+	if to := filters["to"]; to != "" {
+		date, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			loggers.WarnLog.Println(err)
+			return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: "to date should be in YYYY-MM-DD format"}
+		}
+		filter.To = date.AddDate(0, 0, 1)
+	}
 
-	return repo.GetOrdersRepository(UserId)
+	return repo.GetOrdersRepository(UserId, filter)
 }
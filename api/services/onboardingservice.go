@@ -0,0 +1,222 @@
+package services
+
+import (
+	"mime/multipart"
+	"net/http"
+	"path"
+	"path/filepath"
+	"shopping-site/api/repositories"
+	"shopping-site/api/validation"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/pkg/storage"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"strings"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
+)
+
+type IOnboardingService interface {
+	ApplyMerchantService(uuid.UUID, dto.MerchantApplicationRequest, []*multipart.FileHeader) (*models.MerchantApplications, *dto.ErrorResponse)
+	GetUserApplicationsService(uuid.UUID) (*[]models.MerchantApplications, *dto.ErrorResponse)
+	GetMerchantApplicationsService(string) (*[]models.MerchantApplications, *dto.ErrorResponse)
+	GetMerchantApplicationService(string) (*models.MerchantApplications, *dto.ErrorResponse)
+	GetMerchantDocumentService(string, string) (*models.MerchantDocuments, string, *dto.ErrorResponse)
+	ApproveMerchantApplicationService(uuid.UUID, string) *dto.ErrorResponse
+	RejectMerchantApplicationService(uuid.UUID, string, dto.RejectRequest) *dto.ErrorResponse
+}
+
+type onboardingService struct {
+	repositories.IOnboardingRepository
+	storage.Storage
+}
+
+func CommenceOnboardingService(onboarding repositories.IOnboardingRepository, store storage.Storage) IOnboardingService {
+	return &onboardingService{onboarding, store}
+}
+
+func (repo *onboardingService) saveDocument(userId uuid.UUID, fileHeader *multipart.FileHeader) (*models.MerchantDocuments, *dto.ErrorResponse) {
+	extension := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	contentType, ok := constants.AllowedDocumentTypes[extension]
+	if !ok {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "documents should be pdf, png or jpeg files"}
+	}
+
+	if fileHeader.Size > constants.MaxMerchantDocumentSize {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "document size is above the limit"}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+	defer file.Close()
+
+	header := make([]byte, 512)
+	n, _ := file.Read(header)
+	if http.DetectContentType(header[:n]) != contentType {
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "document content does not match its file type"}
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: err.Error()}
+	}
+
+	storagePath, err := repo.Storage.Save(path.Join(constants.MerchantDocumentDir, userId.String(), uuid.NewString()+extension), file)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusInternalServerError,
+			Error: "document upload failed"}
+	}
+
+	return &models.MerchantDocuments{
+		FileName:    filepath.Base(fileHeader.Filename),
+		StoragePath: storagePath,
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+	}, nil
+}
+
+func (repo *onboardingService) removeDocuments(documents []models.MerchantDocuments) {
+	for _, document := range documents {
+		if err := repo.Storage.Remove(document.StoragePath); err != nil {
+			loggers.ErrorLog.Println(err)
+		}
+	}
+}
+
+func (repo *onboardingService) ApplyMerchantService(userIdCtx uuid.UUID, applicationRequest dto.MerchantApplicationRequest, files []*multipart.FileHeader) (*models.MerchantApplications, *dto.ErrorResponse) {
+	applicationRequest.TaxId = strings.ToUpper(strings.TrimSpace(applicationRequest.TaxId))
+	applicationRequest.PayoutRoutingCode = strings.ToUpper(strings.TrimSpace(applicationRequest.PayoutRoutingCode))
+
+	if err := validation.ValidateMerchantApplication(applicationRequest); err != nil {
+		loggers.WarnLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if len(files) == 0 || len(files) > constants.MaxMerchantDocuments {
+		loggers.WarnLog.Println("invalid number of documents")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "between 1 and 5 documents are required"}
+	}
+
+	application := models.MerchantApplications{
+		UserId:              userIdCtx,
+		BusinessName:        applicationRequest.BusinessName,
+		TaxId:               applicationRequest.TaxId,
+		PayoutAccountName:   applicationRequest.PayoutAccountName,
+		PayoutAccountNumber: applicationRequest.PayoutAccountNumber,
+		PayoutRoutingCode:   applicationRequest.PayoutRoutingCode,
+		Status:              constants.Pending,
+	}
+
+	for _, fileHeader := range files {
+		document, errResponse := repo.saveDocument(userIdCtx, fileHeader)
+		if errResponse != nil {
+			repo.removeDocuments(application.Documents)
+			return nil, errResponse
+		}
+
+		application.Documents = append(application.Documents, *document)
+	}
+
+	if errResponse := repo.CreateMerchantApplicationRepository(&application); errResponse != nil {
+		repo.removeDocuments(application.Documents)
+		return nil, errResponse
+	}
+
+	return &application, nil
+}
+
+func (repo *onboardingService) GetUserApplicationsService(userIdCtx uuid.UUID) (*[]models.MerchantApplications, *dto.ErrorResponse) {
+	return repo.GetUserApplicationsRepository(userIdCtx)
+}
+
+func (repo *onboardingService) GetMerchantApplicationsService(status string) (*[]models.MerchantApplications, *dto.ErrorResponse) {
+	if status == "" {
+		status = constants.Pending
+	}
+
+	if status != constants.Pending && status != constants.Approved && status != constants.Rejected {
+		loggers.WarnLog.Println("invalid application status")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "invalid application status"}
+	}
+
+	return repo.GetMerchantApplicationsRepository(status)
+}
+
+func (repo *onboardingService) GetMerchantApplicationService(id string) (*models.MerchantApplications, *dto.ErrorResponse) {
+	applicationId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.GetMerchantApplicationRepository(applicationId)
+}
+
+func (repo *onboardingService) GetMerchantDocumentService(id string, documentId string) (*models.MerchantDocuments, string, *dto.ErrorResponse) {
+	application, errResponse := repo.GetMerchantApplicationService(id)
+	if errResponse != nil {
+		return nil, "", errResponse
+	}
+
+	for _, document := range application.Documents {
+		if document.DocumentId.String() != documentId {
+			continue
+		}
+
+		filePath, err := repo.Storage.Open(document.StoragePath)
+		if err != nil {
+			loggers.ErrorLog.Println(err)
+			return nil, "", &dto.ErrorResponse{Status: fiber.StatusNotFound,
+				Error: "document file is missing"}
+		}
+
+		return &document, filePath, nil
+	}
+
+	return nil, "", &dto.ErrorResponse{Status: fiber.StatusNotFound,
+		Error: "document does not exists"}
+}
+
+func (repo *onboardingService) ApproveMerchantApplicationService(userIdCtx uuid.UUID, id string) *dto.ErrorResponse {
+	applicationId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.ReviewMerchantApplicationRepository(applicationId, userIdCtx, constants.Approved, "")
+}
+
+func (repo *onboardingService) RejectMerchantApplicationService(userIdCtx uuid.UUID, id string, rejectRequest dto.RejectRequest) *dto.ErrorResponse {
+	applicationId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if rejectRequest.Reason == "" {
+		loggers.WarnLog.Println("rejection reason should not be empty")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "rejection reason should not be empty"}
+	}
+
+	return repo.ReviewMerchantApplicationRepository(applicationId, userIdCtx, constants.Rejected, rejectRequest.Reason)
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"regexp"
+	"shopping-site/api/repositories"
+	"shopping-site/api/validation"
+	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
+	"shopping-site/utils/dto"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber"
+	"github.com/google/uuid"
+)
+
+type IReviewService interface {
+	GetProductReviewsService(string) (*[]models.Reviews, *dto.ErrorResponse)
+	AddReviewService(uuid.UUID, string, *models.Reviews) *dto.ErrorResponse
+	UpdateReviewService(uuid.UUID, string, *models.Reviews) *dto.ErrorResponse
+	DeleteReviewService(uuid.UUID, string) *dto.ErrorResponse
+	ReplyReviewService(uuid.UUID, string, dto.ReviewReplyRequest) (*models.Reviews, *dto.ErrorResponse)
+	ReportReviewService(uuid.UUID, string, dto.ReportRequest) *dto.ErrorResponse
+	GetModerationQueueService() (*[]models.Reviews, *dto.ErrorResponse)
+	ModerateReviewService(uuid.UUID, string, string) *dto.ErrorResponse
+	RemoveReviewService(string) *dto.ErrorResponse
+	GetBannedWordsService() (*[]models.BannedWords, *dto.ErrorResponse)
+	AddBannedWordService(dto.BannedWordRequest) (*models.BannedWords, *dto.ErrorResponse)
+	DeleteBannedWordService(string) *dto.ErrorResponse
+}
+
+type bannedWordMatcher struct {
+	mutex   sync.Mutex
+	words   []string
+	pattern *regexp.Regexp
+}
+
+var bannedWordPattern bannedWordMatcher
+
+func (matcher *bannedWordMatcher) compile(bannedWords []models.BannedWords) *regexp.Regexp {
+	words := make([]string, 0, len(bannedWords))
+	for _, word := range bannedWords {
+		words = append(words, word.Word)
+	}
+
+	matcher.mutex.Lock()
+	defer matcher.mutex.Unlock()
+
+	if matcher.pattern != nil && slices.Equal(matcher.words, words) {
+		return matcher.pattern
+	}
+
+	patterns := make([]string, 0, len(words))
+	for _, word := range words {
+		patterns = append(patterns, regexp.QuoteMeta(word))
+	}
+
+	matcher.words = words
+	matcher.pattern = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])(?:` + strings.Join(patterns, "|") + `)(?:$|[^\p{L}\p{N}])`)
+	return matcher.pattern
+}
+
+type reviewService struct {
+	repositories.IReviewRepository
+}
+
+func CommenceReviewService(review repositories.IReviewRepository) IReviewService {
+	return &reviewService{review}
+}
+
+func (repo *reviewService) prepareReview(review *models.Reviews) *dto.ErrorResponse {
+	review.Title = strings.TrimSpace(review.Title)
+	review.Body = strings.TrimSpace(review.Body)
+
+	if err := validation.ValidateReview(*review); err != nil {
+		loggers.WarnLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	words, errResponse := repo.GetBannedWordsRepository()
+	if errResponse != nil {
+		return errResponse
+	} else if len(*words) == 0 {
+		return nil
+	}
+
+	bannedWords := bannedWordPattern.compile(*words)
+	if bannedWords.MatchString(review.Title) || bannedWords.MatchString(review.Body) {
+		loggers.WarnLog.Println("review contains banned words")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "review contains words that are not allowed"}
+	}
+
+	return nil
+}
+
+func (repo *reviewService) GetProductReviewsService(id string) (*[]models.Reviews, *dto.ErrorResponse) {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.GetProductReviewsRepository(productId)
+}
+
+func (repo *reviewService) AddReviewService(userIdCtx uuid.UUID, id string, review *models.Reviews) *dto.ErrorResponse {
+	productId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if errResponse := repo.prepareReview(review); errResponse != nil {
+		return errResponse
+	}
+
+	review.ProductId = productId
+	review.UserId = userIdCtx
+	review.MerchantReply = ""
+	review.RepliedAt = nil
+	review.Status = constants.Published
+	return repo.AddReviewRepository(review)
+}
+
+func (repo *reviewService) UpdateReviewService(userIdCtx uuid.UUID, id string, review *models.Reviews) *dto.ErrorResponse {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	if errResponse := repo.prepareReview(review); errResponse != nil {
+		return errResponse
+	}
+
+	review.ReviewId = reviewId
+	review.UserId = userIdCtx
+	return repo.UpdateReviewRepository(review)
+}
+
+func (repo *reviewService) DeleteReviewService(userIdCtx uuid.UUID, id string) *dto.ErrorResponse {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.DeleteReviewRepository(userIdCtx, reviewId)
+}
+
+func (repo *reviewService) ReplyReviewService(userIdCtx uuid.UUID, id string, replyRequest dto.ReviewReplyRequest) (*models.Reviews, *dto.ErrorResponse) {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	replyRequest.Reply = strings.TrimSpace(replyRequest.Reply)
+	if replyRequest.Reply == "" || len(replyRequest.Reply) > 2000 {
+		loggers.WarnLog.Println("reply length is below or above the limit")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "reply length is below or above the limit"}
+	}
+
+	return repo.ReplyReviewRepository(userIdCtx, reviewId, replyRequest.Reply)
+}
+
+func (repo *reviewService) ReportReviewService(userIdCtx uuid.UUID, id string, reportRequest dto.ReportRequest) *dto.ErrorResponse {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	reportRequest.Reason = strings.TrimSpace(reportRequest.Reason)
+	if reportRequest.Reason == "" || len(reportRequest.Reason) > 500 {
+		loggers.WarnLog.Println("report reason length is below or above the limit")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "report reason length is below or above the limit"}
+	}
+
+	return repo.ReportReviewRepository(&models.ReviewReports{
+		ReviewId: reviewId,
+		UserId:   userIdCtx,
+		Reason:   reportRequest.Reason,
+		Status:   constants.Pending,
+	})
+}
+
+func (repo *reviewService) GetModerationQueueService() (*[]models.Reviews, *dto.ErrorResponse) {
+	return repo.GetModerationQueueRepository()
+}
+
+func (repo *reviewService) ModerateReviewService(userIdCtx uuid.UUID, id string, status string) *dto.ErrorResponse {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.ModerateReviewRepository(userIdCtx, reviewId, status)
+}
+
+func (repo *reviewService) RemoveReviewService(id string) *dto.ErrorResponse {
+	reviewId, err := uuid.Parse(id)
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: err.Error()}
+	}
+
+	return repo.RemoveReviewRepository(reviewId)
+}
+
+func (repo *reviewService) GetBannedWordsService() (*[]models.BannedWords, *dto.ErrorResponse) {
+	return repo.GetBannedWordsRepository()
+}
+
+func (repo *reviewService) AddBannedWordService(wordRequest dto.BannedWordRequest) (*models.BannedWords, *dto.ErrorResponse) {
+	word := strings.ToLower(strings.TrimSpace(wordRequest.Word))
+	if word == "" || len(word) > 50 {
+		loggers.WarnLog.Println("banned word length is below or above the limit")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "banned word length is below or above the limit"}
+	}
+
+	bannedWord := models.BannedWords{Word: word}
+	if errResponse := repo.AddBannedWordRepository(&bannedWord); errResponse != nil {
+		return nil, errResponse
+	}
+
+	return &bannedWord, nil
+}
+
+func (repo *reviewService) DeleteBannedWordService(word string) *dto.ErrorResponse {
+	return repo.DeleteBannedWordRepository(strings.ToLower(strings.TrimSpace(word)))
+}
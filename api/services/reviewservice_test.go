@@ -0,0 +1,39 @@
+package services
+
+import (
+	"shopping-site/pkg/models"
+	"testing"
+)
+
+func TestBannedWordMatcher(t *testing.T) {
+	var matcher bannedWordMatcher
+	words := []models.BannedWords{{Word: "scam"}, {Word: "über"}}
+
+	pattern := matcher.compile(words)
+	tests := []struct {
+		text  string
+		match bool
+	}{
+		{text: "total scam", match: true},
+		{text: "SCAM!", match: true},
+		{text: "scammer", match: false},
+		{text: "Das ist über teuer", match: true},
+		{text: "überall gut", match: false},
+		{text: "Grüßeüber", match: false},
+		{text: "fine product", match: false},
+	}
+
+	for _, test := range tests {
+		if got := pattern.MatchString(test.text); got != test.match {
+			t.Errorf("MatchString(%q) = %v, want %v", test.text, got, test.match)
+		}
+	}
+
+	if matcher.compile([]models.BannedWords{{Word: "scam"}, {Word: "über"}}) != pattern {
+		t.Error("pattern was recompiled for an unchanged word list")
+	}
+
+	if changed := matcher.compile([]models.BannedWords{{Word: "scam"}}); changed == pattern || changed.MatchString("über") {
+		t.Error("pattern was not recompiled after the word list changed")
+	}
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"io"
+	"log"
+	"os"
+	"shopping-site/pkg/loggers"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	discard := log.New(io.Discard, "", 0)
+	loggers.InfoLog, loggers.WarnLog, loggers.ErrorLog, loggers.FatalLog = discard, discard, discard, discard
+
+	os.Exit(m.Run())
+}
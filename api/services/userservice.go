@@ -2,11 +2,17 @@ package services
 
 import (
 	"net/http"
+	"os"
 	"shopping-site/api/repositories"
 	"shopping-site/api/validation"
 	"shopping-site/pkg/loggers"
+	"shopping-site/pkg/mailer"
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/gofiber/fiber"
 	"github.com/google/uuid"
@@ -14,34 +20,56 @@ import (
 )
 
 type IUserService interface {
-	UpdateUserService(uuid.UUID, *models.Users) *dto.ErrorResponse
+	UpdateUserService(uuid.UUID, dto.ProfileUpdateRequest) *dto.ErrorResponse
+	ChangePasswordService(uuid.UUID, uuid.UUID, dto.ChangePasswordRequest) *dto.ErrorResponse
 	PlaceOrderService(uuid.UUID, models.Orders) (*models.Orders, *dto.ErrorResponse)
 	CancelOrderService(uuid.UUID, string) *dto.ErrorResponse
 	GetOrdersService(uuid.UUID) (*[]models.Orders, *dto.ErrorResponse)
-	GetProductsService(map[string]string, uuid.UUID) (*[]models.Products, *dto.ErrorResponse)
+	GetProductsService(dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
 	GetProductService(uuid.UUID, string) (*models.Products, *dto.ErrorResponse)
-	FilterProductsService(map[string]string) (*[]models.Products, *dto.ErrorResponse)
+	SearchProductsService(dto.ProductSearchFilter) (*dto.PaginatedResponse, *dto.ErrorResponse)
 }
 
 type userService struct {
 	repositories.IUserRepository
+	mailer.Mailer
 }
 
-func CommenceUserService(user repositories.IUserRepository) IUserService {
-	return &userService{user}
+func CommenceUserService(user repositories.IUserRepository, mail mailer.Mailer) IUserService {
+	return &userService{user, mail}
 }
 
-func (repo *userService) UpdateUserService(userIdCtx uuid.UUID, user *models.Users) *dto.ErrorResponse {
-	user.UserId = userIdCtx
+func applyProfileUpdate(user *models.Users, request dto.ProfileUpdateRequest) (map[string]interface{}, bool) {
+	updates := map[string]interface{}{}
 
-	if user.FirstName == "" || user.LastName == "" || user.Email == "" || user.Phone == "" || user.Password == "" {
-		loggers.WarnLog.Println("Required fields should not be empty")
-		return &dto.ErrorResponse{
-			Status: fiber.StatusBadRequest,
-			Error:  "Required fields should not be empty"}
+	if request.FirstName != nil {
+		user.FirstName = *request.FirstName
+		updates["first_name"] = user.FirstName
+	}
+
+	if request.LastName != nil {
+		user.LastName = *request.LastName
+		updates["last_name"] = user.LastName
+	}
+
+	if request.Phone != nil {
+		user.Phone = *request.Phone
+		updates["phone"] = user.Phone
+	}
+
+	emailChanged := request.Email != nil && *request.Email != user.Email
+	if emailChanged {
+		user.Email = *request.Email
+		user.IsVerified = false
+		updates["email"] = user.Email
+		updates["is_verified"] = false
 	}
 
-	if err := validation.ValidateUser(*user); err != nil {
+	return updates, emailChanged
+}
+
+func (repo *userService) UpdateUserService(userIdCtx uuid.UUID, request dto.ProfileUpdateRequest) *dto.ErrorResponse {
+	if err := validation.ValidateProfileUpdate(request); err != nil {
 		loggers.WarnLog.Println(err.Error())
 		return &dto.ErrorResponse{
 			Status: fiber.StatusBadRequest,
@@ -49,31 +77,113 @@ func (repo *userService) UpdateUserService(userIdCtx uuid.UUID, user *models.Use
 		}
 	}
 
-	hashedPin, err := bcrypt.GenerateFromPassword([]byte(user.Password), 8)
+	user, errResponse := repo.GetUserRepository(userIdCtx)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	updates, emailChanged := applyProfileUpdate(user, request)
+	if errResponse := repo.UpdateUserRepository(userIdCtx, updates, request.Address); errResponse != nil {
+		return errResponse
+	}
+
+	if emailChanged {
+		if err := sendVerificationEmail(repo.Mailer, user); err != nil {
+			loggers.ErrorLog.Println(err)
+		}
+	}
+
+	return nil
+}
+
+func hashNewPassword(user *models.Users, passwordRequest dto.ChangePasswordRequest) (string, *dto.ErrorResponse) {
+	if passwordRequest.CurrentPassword == "" || passwordRequest.NewPassword == "" {
+		loggers.WarnLog.Println("Required fields should not be empty")
+		return "", &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "Required fields should not be empty"}
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(passwordRequest.CurrentPassword)); err != nil {
+		loggers.WarnLog.Println("invalid current password")
+		return "", &dto.ErrorResponse{
+			Status: fiber.StatusUnauthorized,
+			Error:  "invalid current password"}
+	}
+
+	if err := validation.ValidatePassword(passwordRequest.NewPassword); err != nil {
+		loggers.WarnLog.Println(err.Error())
+		return "", &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  err.Error()}
+	}
+
+	hashedPin, err := bcrypt.GenerateFromPassword([]byte(passwordRequest.NewPassword), 8)
 	if err != nil {
 		loggers.ErrorLog.Println("Password hasing error")
-		return &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "Password hasing error"}
+		return "", &dto.ErrorResponse{Status: http.StatusInternalServerError, Error: "Password hasing error"}
 	}
 
-	user.Password = string(hashedPin)
+	return string(hashedPin), nil
+}
 
-	if len(user.Address) != 0 {
+func (repo *userService) ChangePasswordService(userIdCtx uuid.UUID, sessionIdCtx uuid.UUID, passwordRequest dto.ChangePasswordRequest) *dto.ErrorResponse {
+	user, errResponse := repo.GetUserRepository(userIdCtx)
+	if errResponse != nil {
+		return errResponse
+	}
 
-		for _, data := range user.Address {
-			if data.AddressId == uuid.Nil || data.DoorNo == "" || data.Street == "" || data.City == "" || data.State == "" || data.ZipCode == 0 {
-				loggers.WarnLog.Println("Required Address fields should not be empty")
-				return &dto.ErrorResponse{
-					Status: fiber.StatusBadRequest,
-					Error:  "Required Address fields should not be empty"}
-			}
-		}
+	hashedPassword, errResponse := hashNewPassword(user, passwordRequest)
+	if errResponse != nil {
+		return errResponse
 	}
-	return repo.UpdateUserRepository(user)
+
+	return repo.UpdatePasswordRepository(userIdCtx, sessionIdCtx, hashedPassword)
+}
+
+func requireVerifiedEmail(repo repositories.IUserRepository, userId uuid.UUID) *dto.ErrorResponse {
+	if os.Getenv("REQUIRE_EMAIL_VERIFICATION") != "true" {
+		return nil
+	}
+
+	user, errResponse := repo.GetUserRepository(userId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if !user.IsVerified {
+		loggers.WarnLog.Println("email verification required to place orders")
+		return &dto.ErrorResponse{
+			Status: fiber.StatusForbidden,
+			Error:  "email verification required to place orders"}
+	}
+
+	return nil
 }
 
 func (repo *userService) PlaceOrderService(userIdCtx uuid.UUID, order models.Orders) (*models.Orders, *dto.ErrorResponse) {
 	UserId := userIdCtx
 
+	if errResponse := requireVerifiedEmail(repo.IUserRepository, UserId); errResponse != nil {
+		return nil, errResponse
+	}
+
+	if len(order.Products) == 0 {
+		loggers.WarnLog.Println("order should contain atleast one product")
+		return nil, &dto.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Error:  "order should contain atleast one product"}
+	}
+
+	for _, item := range order.Products {
+		if item.ProductId == uuid.Nil || item.Quantity == 0 {
+			loggers.WarnLog.Println("product id and quantity are required")
+			return nil, &dto.ErrorResponse{
+				Status: fiber.StatusBadRequest,
+				Error:  "product id and quantity are required"}
+		}
+	}
+
 	return repo.PlaceOrderRepository(UserId, order)
 }
 
@@ -94,10 +204,62 @@ func (repo *userService) GetOrdersService(userIdCtx uuid.UUID) (*[]models.Orders
 	return repo.GetOrdersRepository(UserId)
 }
 
-func (repo *userService) GetProductsService(filters map[string]string, userIdCtx uuid.UUID) (*[]models.Products, *dto.ErrorResponse) {
-	UserId := userIdCtx
+func paginate(page *int, limit *int) {
+	if *page < 1 {
+		*page = 1
+	}
 
-	return repo.GetProductsRepository(filters, UserId)
+	if *limit < 1 {
+		*limit = constants.DefaultPageSize
+	} else if *limit > constants.MaxPageSize {
+		*limit = constants.MaxPageSize
+	}
+}
+
+func prepareProductFilter(filter *dto.ProductFilter) *dto.ErrorResponse {
+	if filter.Sort == "" {
+		filter.Sort = constants.SortNewest
+	} else if !slices.Contains(constants.ProductSorts, filter.Sort) {
+		loggers.WarnLog.Println("invalid sort option")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "sort should be one of " + strings.Join(constants.ProductSorts, ", ")}
+	}
+
+	if filter.Status != "" && filter.Status != constants.Pending && filter.Status != constants.Approved && filter.Status != constants.Rejected {
+		loggers.WarnLog.Println("invalid approval status")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "status should be one of pending, approved or rejected"}
+	}
+
+	if filter.MinPrice < 0 || filter.MaxPrice < 0 || filter.Rating < 0 || (filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice) {
+		loggers.WarnLog.Println("invalid price or rating range")
+		return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "invalid price or rating range"}
+	}
+
+	if filter.Merchant != "" {
+		merchantId, err := uuid.Parse(filter.Merchant)
+		if err != nil {
+			loggers.ErrorLog.Println(err)
+			return &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+				Error: err.Error()}
+		}
+
+		filter.MerchantId = merchantId
+	}
+
+	paginate(&filter.Page, &filter.Limit)
+	return nil
+}
+
+func (repo *userService) GetProductsService(filter dto.ProductFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	filter.Status = ""
+	if errResponse := prepareProductFilter(&filter); errResponse != nil {
+		return nil, errResponse
+	}
+
+	filter.ApprovedOnly = true
+	return repo.GetProductsRepository(filter)
 }
 
 func (repo *userService) GetProductService(userIdCtx uuid.UUID, id string) (*models.Products, *dto.ErrorResponse) {
@@ -111,6 +273,27 @@ func (repo *userService) GetProductService(userIdCtx uuid.UUID, id string) (*mod
 	return repo.GetProductRepository(UserId, productId)
 }
 
-func (repo *userService) FilterProductsService(filters map[string]string) (*[]models.Products, *dto.ErrorResponse) {
-	return repo.FilterProductsRepository(filters)
+func (repo *userService) SearchProductsService(filter dto.ProductSearchFilter) (*dto.PaginatedResponse, *dto.ErrorResponse) {
+	terms := strings.FieldsFunc(strings.ToLower(filter.Query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) == 0 {
+		loggers.WarnLog.Println("search query should not be empty")
+		return nil, &dto.ErrorResponse{Status: fiber.StatusBadRequest,
+			Error: "search query should not be empty"}
+	}
+
+	for i := range terms {
+		terms[i] += ":*"
+	}
+	filter.Query = strings.Join(strings.Fields(filter.Query), " ")
+
+	paginate(&filter.Page, &filter.Limit)
+
+	results, total, errResponse := repo.SearchProductsRepository(filter, strings.Join(terms, " & "))
+	if errResponse != nil {
+		return nil, errResponse
+	}
+
+	return &dto.PaginatedResponse{Items: results, Page: filter.Page, Limit: filter.Limit, Total: total}, nil
 }
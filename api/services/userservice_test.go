@@ -0,0 +1,63 @@
+package services
+
+import (
+	"shopping-site/api/validation"
+	"shopping-site/pkg/models"
+	"shopping-site/utils/dto"
+	"testing"
+)
+
+func TestApplyProfileUpdateOnlyTouchesPresentFields(t *testing.T) {
+	user := models.Users{FirstName: "Arjun", LastName: "Kumar", Email: "arjun@example.com", Phone: "9876543210", IsVerified: true}
+	phone := "9123456780"
+
+	updates, emailChanged := applyProfileUpdate(&user, dto.ProfileUpdateRequest{Phone: &phone})
+	if emailChanged {
+		t.Error("email reported as changed when it was not sent")
+	}
+
+	if len(updates) != 1 || updates["phone"] != phone {
+		t.Fatalf("updates = %v, want only the phone", updates)
+	}
+
+	if user.FirstName != "Arjun" || user.Email != "arjun@example.com" || !user.IsVerified {
+		t.Fatalf("absent fields were modified: %+v", user)
+	}
+
+	sameEmail := "arjun@example.com"
+	if updates, emailChanged := applyProfileUpdate(&user, dto.ProfileUpdateRequest{Email: &sameEmail}); emailChanged || len(updates) != 0 {
+		t.Fatalf("unchanged email produced updates %v (changed=%v)", updates, emailChanged)
+	}
+
+	newEmail := "arjun.k@example.com"
+	updates, emailChanged = applyProfileUpdate(&user, dto.ProfileUpdateRequest{Email: &newEmail})
+	if !emailChanged || updates["email"] != newEmail || updates["is_verified"] != false || user.IsVerified {
+		t.Fatalf("email change = (%v, %v), want email and is_verified=false", updates, emailChanged)
+	}
+}
+
+func TestValidateProfileUpdate(t *testing.T) {
+	short := "Al"
+	name := "Meena"
+	badEmail := "not-an-email"
+	phone := "9876543210"
+
+	tests := []struct {
+		name    string
+		request dto.ProfileUpdateRequest
+		valid   bool
+	}{
+		{name: "empty body", request: dto.ProfileUpdateRequest{}},
+		{name: "phone only", request: dto.ProfileUpdateRequest{Phone: &phone}, valid: true},
+		{name: "last name only", request: dto.ProfileUpdateRequest{LastName: &name}, valid: true},
+		{name: "short last name", request: dto.ProfileUpdateRequest{LastName: &short}},
+		{name: "invalid email", request: dto.ProfileUpdateRequest{Email: &badEmail}},
+		{name: "address without id", request: dto.ProfileUpdateRequest{Address: []models.Addresses{{DoorNo: "1", Street: "Main", City: "Chennai", State: "Tamil Nadu", ZipCode: 600001}}}},
+	}
+
+	for _, test := range tests {
+		if err := validation.ValidateProfileUpdate(test.request); (err == nil) != test.valid {
+			t.Errorf("%s: ValidateProfileUpdate error = %v, want valid=%v", test.name, err, test.valid)
+		}
+	}
+}
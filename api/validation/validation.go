@@ -4,10 +4,23 @@ import (
 	"fmt"
 	"regexp"
 	"shopping-site/pkg/models"
+	"shopping-site/utils/constants"
 	"shopping-site/utils/dto"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 func ValidateUser(user models.Users) error {
+	if err := ValidateProfile(user); err != nil {
+		return err
+	}
+
+	return ValidatePassword(user.Password)
+}
+
+func ValidateProfile(user models.Users) error {
 	if (user.FirstName == "") || (user.LastName == "") {
 		return fmt.Errorf("both first and last name is manditory")
 	}
@@ -20,10 +33,6 @@ func ValidateUser(user models.Users) error {
 		return fmt.Errorf("last name length is below or above the limit")
 	}
 
-	if len(user.Password) <= 7 || len(user.Password) >= 15 {
-		return fmt.Errorf("password length is below or above the limit")
-	}
-
 	if len(user.Phone) != 10 {
 		return fmt.Errorf("invalid phone number")
 	}
@@ -33,6 +42,71 @@ func ValidateUser(user models.Users) error {
 		return fmt.Errorf("invalid email format")
 	}
 
+	for _, address := range user.Address {
+		if err := ValidateAddress(address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateProfileUpdate(request dto.ProfileUpdateRequest) error {
+	if request.FirstName == nil && request.LastName == nil && request.Email == nil && request.Phone == nil && len(request.Address) == 0 {
+		return fmt.Errorf("no profile fields to update")
+	}
+
+	if request.FirstName != nil && (len(*request.FirstName) <= 3 || len(*request.FirstName) >= 10) {
+		return fmt.Errorf("first name length is below or above the limit")
+	}
+
+	if request.LastName != nil && (len(*request.LastName) <= 3 || len(*request.LastName) >= 10) {
+		return fmt.Errorf("last name length is below or above the limit")
+	}
+
+	if request.Phone != nil && len(*request.Phone) != 10 {
+		return fmt.Errorf("invalid phone number")
+	}
+
+	regxEmail := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
+	if request.Email != nil && !regxEmail.MatchString(*request.Email) {
+		return fmt.Errorf("invalid email format")
+	}
+
+	for _, address := range request.Address {
+		if address.AddressId == uuid.Nil {
+			return fmt.Errorf("address id is manditory")
+		}
+
+		if err := ValidateAddress(address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateAddress(address models.Addresses) error {
+	if strings.TrimSpace(address.DoorNo) == "" || strings.TrimSpace(address.Street) == "" || strings.TrimSpace(address.City) == "" {
+		return fmt.Errorf("door number, street and city are manditory")
+	}
+
+	if address.ZipCode < 100000 || address.ZipCode > 999999 {
+		return fmt.Errorf("invalid zip code")
+	}
+
+	if !slices.ContainsFunc(constants.States, func(state string) bool { return strings.EqualFold(state, strings.TrimSpace(address.State)) }) {
+		return fmt.Errorf("invalid state")
+	}
+
+	return nil
+}
+
+func ValidatePassword(password string) error {
+	if len(password) <= 7 || len(password) >= 15 {
+		return fmt.Errorf("password length is below or above the limit")
+	}
+
 	return nil
 }
 
@@ -47,3 +121,48 @@ func ValidateLogin(loginRequest dto.LoginRequest) error {
 
 	return nil
 }
+
+func ValidateOrderStatus(orderStatus string) error {
+	switch orderStatus {
+	case constants.Shipped, constants.OutForDelivery, constants.Delivered, constants.Cancelled:
+		return nil
+	}
+
+	return fmt.Errorf("invalid order status")
+}
+
+func ValidateMerchantApplication(application dto.MerchantApplicationRequest) error {
+	if application.BusinessName == "" || application.PayoutAccountName == "" {
+		return fmt.Errorf("business name and payout account name are manditory")
+	}
+
+	if !regexp.MustCompile(`^[A-Z0-9]{8,20}$`).MatchString(application.TaxId) {
+		return fmt.Errorf("invalid tax id")
+	}
+
+	if !regexp.MustCompile(`^[0-9]{6,20}$`).MatchString(application.PayoutAccountNumber) {
+		return fmt.Errorf("invalid payout account number")
+	}
+
+	if !regexp.MustCompile(`^[A-Z0-9]{4,15}$`).MatchString(application.PayoutRoutingCode) {
+		return fmt.Errorf("invalid payout routing code")
+	}
+
+	return nil
+}
+
+func ValidateReview(review models.Reviews) error {
+	if review.Stars < 1 || review.Stars > 5 {
+		return fmt.Errorf("stars should be between 1 and 5")
+	}
+
+	if strings.TrimSpace(review.Title) == "" || len(review.Title) > 100 {
+		return fmt.Errorf("title length is below or above the limit")
+	}
+
+	if len(review.Body) > 2000 {
+		return fmt.Errorf("review body length is above the limit")
+	}
+
+	return nil
+}
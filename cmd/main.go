@@ -5,6 +5,7 @@ import (
 	"shopping-site/api/routers"
 	"shopping-site/internals"
 	"shopping-site/pkg/loggers"
+	"shopping-site/utils/constants"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,7 +19,7 @@ func main() {
 	db := internals.InitiatePgConnection()
 	internals.SchemaMigration(db)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{BodyLimit: constants.MaxRequestBodySize})
 	routers.RequiredRoute(app, db)
 
 	err := app.Listen(os.Getenv("CLIENTPORT"))
@@ -4,16 +4,287 @@ import (
 	"fmt"
 	"shopping-site/pkg/loggers"
 	"shopping-site/pkg/models"
+	"shopping-site/pkg/totp"
+	"shopping-site/utils/constants"
+	"slices"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func SchemaMigration(db *gorm.DB) {
-	err := db.AutoMigrate(&models.Users{}, &models.Addresses{}, &models.Categories{}, &models.Brands{}, &models.Products{}, &models.Orders{}, &models.OrderedItems{})
+	mergeDuplicateCartItems(db)
+
+	err := db.AutoMigrate(&models.Users{}, &models.Addresses{}, &models.Categories{}, &models.Brands{}, &models.Products{}, &models.Orders{}, &models.Shipments{}, &models.OrderedItems{}, &models.OrderStatusHistory{}, &models.Carts{}, &models.CartItems{}, &models.Sessions{}, &models.RefreshTokens{}, &models.PasswordResetTokens{}, &models.TwoFactorChallenges{}, &models.RecoveryCodes{}, &models.TwoFactorPolicies{}, &models.LoginAttempts{}, &models.Permissions{}, &models.RolePermissions{}, &models.MerchantApplications{}, &models.MerchantDocuments{}, &models.Reviews{}, &models.ReviewReports{}, &models.BannedWords{})
 	if err != nil {
 		loggers.FatalLog.Fatal("Error while migrating tables")
 	}
 
+	dropLegacyReviewIndex(db)
+	backfillShipments(db)
+	backfillSlugs(db)
+	backfillShippingAddresses(db)
+	syncProductRatings(db)
+	encryptTwoFactorSecrets(db)
+	setupProductSearch(db)
+	dropProductFunctions(db)
+	seedPermissions(db)
+
 	loggers.InfoLog.Print("Migration Completed")
 	fmt.Println("Migration Completed")
 }
+
+func mergeDuplicateCartItems(db *gorm.DB) {
+	if !db.Migrator().HasTable(&models.CartItems{}) || db.Migrator().HasIndex(&models.CartItems{}, "idx_cart_product") {
+		return
+	}
+
+	err := db.Exec(`WITH merged AS (
+			SELECT cart_id, product_id, SUM(quantity) AS quantity, MIN(cart_item_id::text)::uuid AS cart_item_id
+			FROM cart_items GROUP BY cart_id, product_id HAVING COUNT(*) > 1
+		), removed AS (
+			DELETE FROM cart_items AS ci USING merged AS m
+			WHERE ci.cart_id = m.cart_id AND ci.product_id = m.product_id AND ci.cart_item_id <> m.cart_item_id
+		)
+		UPDATE cart_items AS ci SET quantity = m.quantity FROM merged AS m WHERE ci.cart_item_id = m.cart_item_id`).Error
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while merging duplicate cart items")
+	}
+}
+
+func dropLegacyReviewIndex(db *gorm.DB) {
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_reviews_product_user`).Error; err != nil {
+		loggers.FatalLog.Fatal("Error while dropping legacy review index")
+	}
+}
+
+func backfillShipments(db *gorm.DB) {
+	var pending []struct {
+		OrderId    uuid.UUID
+		MerchantId *uuid.UUID
+		Status     string
+		CreatedAt  time.Time
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw(`SELECT oi.order_id, p.user_id AS merchant_id, o.status, o.created_at
+			FROM ordered_items AS oi
+			INNER JOIN orders AS o ON o.order_id = oi.order_id
+			LEFT JOIN products AS p ON p.product_id = oi.product_id
+			WHERE oi.shipment_id IS NULL
+			GROUP BY oi.order_id, p.user_id, o.status, o.created_at`).Scan(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		for _, group := range pending {
+			var shipment models.Shipments
+
+			record := tx.Where("order_id = ? AND merchant_id IS NOT DISTINCT FROM ?", group.OrderId, group.MerchantId).Limit(1).Find(&shipment)
+			if record.Error != nil {
+				return record.Error
+			}
+
+			if record.RowsAffected == 0 {
+				shipment = models.Shipments{OrderId: group.OrderId, MerchantId: group.MerchantId, Status: group.Status, CreatedAt: group.CreatedAt, UpdatedAt: group.CreatedAt}
+				if err := tx.Create(&shipment).Error; err != nil {
+					return err
+				}
+			}
+
+			err := tx.Exec(`UPDATE ordered_items AS oi SET shipment_id = ?
+				WHERE oi.shipment_id IS NULL AND oi.order_id = ?
+				AND (SELECT p.user_id FROM products AS p WHERE p.product_id = oi.product_id) IS NOT DISTINCT FROM ?`,
+				shipment.ShipmentId, group.OrderId, group.MerchantId).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while backfilling shipments")
+	}
+}
+
+func backfillSlugs(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for table, columns := range map[string][2]string{
+			"categories": {"category_id", "category_name"},
+			"brands":     {"brand_id", "brand_name"},
+		} {
+			err := tx.Exec(fmt.Sprintf(`UPDATE %[1]s AS t SET slug = s.slug
+				FROM (
+					SELECT %[2]s, CASE WHEN base = '' THEN left(%[2]s::text, 8)
+						WHEN row_number() OVER (PARTITION BY base ORDER BY %[2]s) > 1 THEN base || '-' || left(%[2]s::text, 8)
+						ELSE base END AS slug
+					FROM (
+						SELECT %[2]s, trim(both '-' from regexp_replace(lower(%[3]s), '[^a-z0-9]+', '-', 'g')) AS base
+						FROM %[1]s WHERE slug IS NULL OR slug = ''
+					) AS b
+				) AS s
+				WHERE t.%[2]s = s.%[2]s`, table, columns[0], columns[1])).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while backfilling slugs")
+	}
+}
+
+func backfillShippingAddresses(db *gorm.DB) {
+	err := db.Exec(`UPDATE orders AS o SET shipping_door_no = a.door_no, shipping_street = a.street,
+		shipping_city = a.city, shipping_state = a.state, shipping_zip_code = a.zip_code
+		FROM addresses AS a
+		WHERE a.address_id = o.address_id AND o.shipping_zip_code IS NULL`).Error
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while backfilling shipping addresses")
+	}
+}
+
+func syncProductRatings(db *gorm.DB) {
+	err := db.Exec(`UPDATE products AS p SET rating = COALESCE(r.rating, 0), review_count = COALESCE(r.review_count, 0)
+		FROM products AS pr
+		LEFT JOIN (SELECT product_id, ROUND(AVG(stars), 2) AS rating, COUNT(*) AS review_count FROM reviews WHERE status = 'published' AND deleted_at IS NULL GROUP BY product_id) AS r
+		ON r.product_id = pr.product_id
+		WHERE p.product_id = pr.product_id AND (p.rating <> COALESCE(r.rating, 0) OR p.review_count <> COALESCE(r.review_count, 0))`).Error
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while syncing product ratings")
+	}
+}
+
+func encryptTwoFactorSecrets(db *gorm.DB) {
+	var users []models.Users
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		record := tx.Select("user_id", "two_factor_secret").Where("two_factor_secret <> '' AND two_factor_secret NOT LIKE 'enc:%'").Find(&users)
+		if record.Error != nil || len(users) == 0 {
+			return record.Error
+		}
+
+		key, err := totp.EncryptionKey()
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			sealed, err := totp.SealSecret(user.TwoFactorSecret, key)
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Model(&models.Users{}).Where("user_id = ?", user.UserId).Update("two_factor_secret", sealed).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while encrypting two-factor secrets: ", err)
+	}
+}
+
+func setupProductSearch(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range []string{
+			`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+			`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+			`CREATE OR REPLACE FUNCTION products_search_vector_fn() RETURNS trigger AS $$
+			BEGIN
+				NEW.search_vector :=
+					setweight(to_tsvector('simple', coalesce(NEW.product_name, '')), 'A') ||
+					setweight(to_tsvector('simple', coalesce((SELECT brand_name FROM brands WHERE brand_id = NEW.brand_id), '')), 'B') ||
+					setweight(to_tsvector('simple', coalesce((SELECT category_name FROM categories WHERE category_id = NEW.category_id), '')), 'B') ||
+					setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'C');
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql`,
+			`CREATE OR REPLACE FUNCTION products_search_vector_refresh_fn() RETURNS trigger AS $$
+			BEGIN
+				IF TG_TABLE_NAME = 'brands' THEN
+					UPDATE products SET brand_id = brand_id WHERE brand_id = NEW.brand_id;
+				ELSE
+					UPDATE products SET category_id = category_id WHERE category_id = NEW.category_id;
+				END IF;
+				RETURN NULL;
+			END;
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS products_search_vector_trg ON products`,
+			`CREATE TRIGGER products_search_vector_trg BEFORE INSERT OR UPDATE OF product_name, description, brand_id, category_id
+				ON products FOR EACH ROW EXECUTE FUNCTION products_search_vector_fn()`,
+			`DROP TRIGGER IF EXISTS brands_search_vector_trg ON brands`,
+			`CREATE TRIGGER brands_search_vector_trg AFTER UPDATE OF brand_name
+				ON brands FOR EACH ROW EXECUTE FUNCTION products_search_vector_refresh_fn()`,
+			`DROP TRIGGER IF EXISTS categories_search_vector_trg ON categories`,
+			`CREATE TRIGGER categories_search_vector_trg AFTER UPDATE OF category_name
+				ON categories FOR EACH ROW EXECUTE FUNCTION products_search_vector_refresh_fn()`,
+			`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)`,
+			`CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (product_name gin_trgm_ops)`,
+			`UPDATE products SET product_name = product_name WHERE search_vector IS NULL`,
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while setting up product search")
+	}
+}
+
+func dropProductFunctions(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range []string{
+			`DROP FUNCTION IF EXISTS getProductsUser_fn(text, text)`,
+			`DROP FUNCTION IF EXISTS filterProductsUser_fn(numeric, numeric)`,
+			`DROP FUNCTION IF EXISTS getProductsMerchant_fn(uuid, text, text)`,
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while dropping product functions")
+	}
+}
+
+func seedPermissions(db *gorm.DB) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for permissionName, description := range constants.PermissionRegistry {
+			record := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Permissions{PermissionName: permissionName, Description: description})
+			if record.Error != nil {
+				return record.Error
+			} else if record.RowsAffected == 0 {
+				continue
+			}
+
+			for role, permissions := range constants.DefaultRolePermissions {
+				if !slices.Contains(permissions, permissionName) {
+					continue
+				}
+
+				if err := tx.Create(&models.RolePermissions{Role: role, PermissionName: permissionName}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		loggers.FatalLog.Fatal("Error while seeding permissions")
+	}
+}
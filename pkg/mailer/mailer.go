@@ -0,0 +1,98 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"shopping-site/pkg/loggers"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+type fileMailer struct {
+	path  string
+	mutex sync.Mutex
+}
+
+type smtpMailer struct {
+	address  string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func NewMailer() Mailer {
+	if os.Getenv("MAILER") == "smtp" {
+		return NewSmtpMailer(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
+	}
+
+	return NewFileMailer(os.Getenv("MAILER_FILE_PATH"))
+}
+
+func NewFileMailer(path string) Mailer {
+	if path != "" {
+		if workingDir, err := os.Getwd(); err == nil {
+			path = filepath.Join(filepath.Dir(workingDir), path)
+		}
+	}
+
+	return &fileMailer{path: path}
+}
+
+func NewSmtpMailer(host string, port string, username string, password string, from string) Mailer {
+	return &smtpMailer{
+		address:  host + ":" + port,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (mailer *fileMailer) Send(to string, subject string, body string) error {
+	message := fmt.Sprintf("To: %s\nSubject: %s\nDate: %s\n\n%s\n\n", to, subject, time.Now().Format(time.RFC1123Z), body)
+
+	if mailer.path == "" {
+		loggers.InfoLog.Print(message)
+		return nil
+	}
+
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	file, err := os.OpenFile(mailer.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0664)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(message)
+	return err
+}
+
+func (mailer *smtpMailer) Send(to string, subject string, body string) error {
+	var auth smtp.Auth
+	if mailer.username != "" {
+		auth = smtp.PlainAuth("", mailer.username, mailer.password, mailer.host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + mailer.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(mailer.address, auth, mailer.from, []string{to}, []byte(message))
+}
@@ -0,0 +1,118 @@
+package mailer
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+type smtpEnvelope struct {
+	from string
+	to   []string
+	data string
+}
+
+func startFakeSmtpServer(t *testing.T) (string, string, <-chan smtpEnvelope) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	envelopes := make(chan smtpEnvelope, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var envelope smtpEnvelope
+
+		text.PrintfLine("220 localhost fake smtp")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				text.PrintfLine("250 localhost")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				envelope.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				text.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				envelope.to = append(envelope.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				text.PrintfLine("250 OK")
+			case command == "DATA":
+				text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+				lines, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				envelope.data = strings.Join(lines, "\n")
+				text.PrintfLine("250 OK")
+			case command == "QUIT":
+				text.PrintfLine("221 bye")
+				envelopes <- envelope
+				return
+			default:
+				text.PrintfLine("502 command not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return host, port, envelopes
+}
+
+func TestSmtpMailerSendVerificationMail(t *testing.T) {
+	host, port, envelopes := startFakeSmtpServer(t)
+	mailer := NewSmtpMailer(host, port, "", "", "no-reply@shopping-site.test")
+
+	body := "Hi Test,\n\nPlease verify your email address by opening the link below:\nhttp://localhost/verify-email?token=abc.def.ghi\n\nThe link expires in 24h0m0s."
+	if err := mailer.Send("user@example.com", "Verify your email address", body); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	envelope := <-envelopes
+	if envelope.from != "no-reply@shopping-site.test" {
+		t.Errorf("MAIL FROM = %q, want %q", envelope.from, "no-reply@shopping-site.test")
+	}
+
+	if len(envelope.to) != 1 || envelope.to[0] != "user@example.com" {
+		t.Errorf("RCPT TO = %v, want [user@example.com]", envelope.to)
+	}
+
+	headers, content, ok := strings.Cut(envelope.data, "\n\n")
+	if !ok {
+		t.Fatalf("message has no header separator: %q", envelope.data)
+	}
+
+	for _, header := range []string{
+		"From: no-reply@shopping-site.test",
+		"To: user@example.com",
+		"Subject: Verify your email address",
+		"Content-Type: text/plain; charset=\"utf-8\"",
+	} {
+		if !strings.Contains(headers+"\n", header+"\n") {
+			t.Errorf("headers missing %q:\n%s", header, headers)
+		}
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(headers + "\n\n")))
+	if _, err := reader.ReadMIMEHeader(); err != nil {
+		t.Errorf("headers do not parse: %v", err)
+	}
+
+	if content != body {
+		t.Errorf("body = %q, want %q", content, body)
+	}
+}
@@ -14,74 +14,273 @@ type Base struct {
 }
 
 type Users struct {
-	UserId     uuid.UUID   `json:"user_id,omitempty" gorm:"type:uuid;primaryKey"`
-	FirstName  string      `json:"first_name,omitempty" gorm:"not null"`
-	LastName   string      `json:"last_name,omitempty" gorm:"not null"`
-	Email      string      `json:"email,omitempty" gorm:"unique;not null"`
-	Phone      string      `json:"phone,omitempty" gorm:"unique;not null"`
-	Password   string      `json:"password,omitempty" gorm:"not null"`
-	Role       string      `json:"role,omitempty" gorm:"not null;check:role= 'user' or role= 'merchant' or role='admin'"`
-	IsVerified bool        `json:"is_verified,omitempty" gorm:"not null"`
-	Address    []Addresses `json:"address,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Product    []Products  `json:"product,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Order      []Orders    `json:"order,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	UserId            uuid.UUID   `json:"user_id,omitempty" gorm:"type:uuid;primaryKey"`
+	FirstName         string      `json:"first_name,omitempty" gorm:"not null"`
+	LastName          string      `json:"last_name,omitempty" gorm:"not null"`
+	Email             string      `json:"email,omitempty" gorm:"unique;not null"`
+	Phone             string      `json:"phone,omitempty" gorm:"unique;not null"`
+	Password          string      `json:"password,omitempty" gorm:"not null"`
+	Role              string      `json:"role,omitempty" gorm:"not null;check:role= 'user' or role= 'merchant' or role='admin'"`
+	IsVerified        bool        `json:"is_verified,omitempty" gorm:"not null"`
+	TwoFactorEnabled  bool        `json:"two_factor_enabled,omitempty" gorm:"not null;default:false"`
+	TwoFactorSecret   string      `json:"-"`
+	TwoFactorLastStep int64       `json:"-" gorm:"not null;default:0"`
+	SuspendedAt       *time.Time  `json:"suspended_at,omitempty"`
+	SuspensionReason  string      `json:"suspension_reason,omitempty"`
+	MustResetPassword bool        `json:"must_reset_password,omitempty" gorm:"not null;default:false"`
+	Address           []Addresses `json:"address,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Product           []Products  `json:"product,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Order             []Orders    `json:"order,omitempty" gorm:"foreignKey:UserId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	Base
 }
 
 type Addresses struct {
-	AddressId uuid.UUID `json:"address_id,omitempty" gorm:"type:uuid;primaryKey;not null"`
-	DoorNo    string    `json:"door_no,omitempty" gorm:"not null"`
-	Street    string    `json:"street,omitempty" gorm:"not null"`
-	City      string    `json:"city,omitempty" gorm:"not null"`
-	State     string    `json:"state,omitempty" gorm:"not null"`
-	ZipCode   uint      `json:"zip_code,omitempty" gorm:"not null"`
-	UserId    uuid.UUID `json:"user_id,omitempty"`
-	Order     Orders    `json:"-" gorm:"foreignKey:AddressId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	AddressId         uuid.UUID `json:"address_id,omitempty" gorm:"type:uuid;primaryKey;not null"`
+	DoorNo            string    `json:"door_no,omitempty" gorm:"not null"`
+	Street            string    `json:"street,omitempty" gorm:"not null"`
+	City              string    `json:"city,omitempty" gorm:"not null"`
+	State             string    `json:"state,omitempty" gorm:"not null"`
+	ZipCode           uint      `json:"zip_code,omitempty" gorm:"not null"`
+	UserId            uuid.UUID `json:"user_id,omitempty"`
+	IsDefaultShipping bool      `json:"is_default_shipping" gorm:"not null;default:false"`
+	IsDefaultBilling  bool      `json:"is_default_billing" gorm:"not null;default:false"`
+	Order             Orders    `json:"-" gorm:"foreignKey:AddressId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Base
 }
 
 type Categories struct {
-	CategoryId   uuid.UUID  `json:"category_id,omitempty" gorm:"type:uuid;primaryKey"`
-	CategoryName string     `json:"category_name,omitempty" gorm:"not null"`
-	Product      []Products `json:"product,omitempty" gorm:"foreignKey:CategoryId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	CategoryId   uuid.UUID    `json:"category_id,omitempty" gorm:"type:uuid;primaryKey"`
+	CategoryName string       `json:"category_name,omitempty" gorm:"not null"`
+	Slug         string       `json:"slug,omitempty" gorm:"uniqueIndex:idx_categories_slug,where:deleted_at IS NULL"`
+	ParentId     *uuid.UUID   `json:"parent_id,omitempty" gorm:"type:uuid;index"`
+	Children     []Categories `json:"children,omitempty" gorm:"foreignKey:ParentId;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Product      []Products   `json:"product,omitempty" gorm:"foreignKey:CategoryId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Base
 }
 
 type Brands struct {
 	BrandId   uuid.UUID  `json:"brand_id,omitempty" gorm:"type:uuid;primaryKey"`
 	BrandName string     `json:"brand_name,omitempty" gorm:"not null"`
+	Slug      string     `json:"slug,omitempty" gorm:"uniqueIndex:idx_brands_slug,where:deleted_at IS NULL"`
 	Product   []Products `json:"product,omitempty" gorm:"foreignKey:BrandId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Base
 }
 
 type Products struct {
-	ProductId   uuid.UUID `json:"product_id,omitempty" gorm:"type:uuid;primaryKey;not null"`
-	ProductName string    `json:"product_name,omitempty" gorm:"not null"`
-	CategoryId  uuid.UUID `json:"category_id,omitempty" gorm:"not null"`
-	BrandId     uuid.UUID `json:"brand_id,omitempty" gorm:"not null"`
-	UserId      uuid.UUID `json:"user_id,omitempty" gorm:"not null"`
-	Price       float64   `json:"price,omitempty" gorm:"not null"`
-	Rating      float32   `json:"rating,omitempty" gorm:"not null"`
-	IsApproved  bool      `json:"is_Approved,omitempty" gorm:"not null"`
+	ProductId       uuid.UUID `json:"product_id,omitempty" gorm:"type:uuid;primaryKey;not null"`
+	ProductName     string    `json:"product_name,omitempty" gorm:"not null"`
+	Description     string    `json:"description,omitempty"`
+	CategoryId      uuid.UUID `json:"category_id,omitempty" gorm:"not null"`
+	BrandId         uuid.UUID `json:"brand_id,omitempty" gorm:"not null"`
+	UserId          uuid.UUID `json:"user_id,omitempty" gorm:"not null"`
+	Price           float64   `json:"price,omitempty" gorm:"not null"`
+	Rating          float32   `json:"rating,omitempty" gorm:"not null;default:0"`
+	ReviewCount     uint      `json:"review_count" gorm:"not null;default:0"`
+	Stock           uint      `json:"stock" gorm:"not null;default:0;check:stock >= 0"`
+	IsApproved      bool      `json:"is_Approved,omitempty" gorm:"not null"`
+	ApprovalStatus  string    `json:"approval_status,omitempty" gorm:"not null;default:pending"`
+	RejectionReason string    `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at,omitempty" gorm:"not null;default:CURRENT_TIMESTAMP;autoCreateTime"`
 }
 
 type Orders struct {
-	OrderId     uuid.UUID      `json:"ordered_id,omitempty" gorm:"type:uuid;primaryKey"`
-	UserId      uuid.UUID      `json:"user_id,omitempty" gorm:"not null"`
-	AddressId   uuid.UUID      `json:"address_id,omitempty" gorm:"not null"`
-	Name        string         `json:"name,omitempty" gorm:"not null"`
-	Email       string         `json:"first_name,omitempty" gorm:"not null"`
-	Phone       string         `json:"phone,omitempty" gorm:"not null"`
-	Products    []OrderedItems `json:"products,omitempty" gorm:"foreignKey:OrderId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	TotalAmount float64        `json:"total_amount,omitempty" gorm:"null"`
-	Status      string         `json:"status,omitempty"`
-	CreatedAt   time.Time      `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	OrderId       uuid.UUID            `json:"ordered_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId        uuid.UUID            `json:"user_id,omitempty" gorm:"not null"`
+	AddressId     uuid.UUID            `json:"address_id,omitempty" gorm:"not null"`
+	Address       ShippingAddress      `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
+	Name          string               `json:"name,omitempty" gorm:"not null"`
+	Email         string               `json:"first_name,omitempty" gorm:"not null"`
+	Phone         string               `json:"phone,omitempty" gorm:"not null"`
+	Products      []OrderedItems       `json:"products,omitempty" gorm:"foreignKey:OrderId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	TotalAmount   float64              `json:"total_amount,omitempty" gorm:"null"`
+	Status        string               `json:"status,omitempty"`
+	Shipments     []Shipments          `json:"shipments,omitempty" gorm:"foreignKey:OrderId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	StatusHistory []OrderStatusHistory `json:"status_history,omitempty" gorm:"foreignKey:OrderId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt     time.Time            `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type ShippingAddress struct {
+	DoorNo  string `json:"door_no,omitempty"`
+	Street  string `json:"street,omitempty"`
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty"`
+	ZipCode uint   `json:"zip_code,omitempty"`
+}
+
+type Shipments struct {
+	ShipmentId     uuid.UUID      `json:"shipment_id,omitempty" gorm:"type:uuid;primaryKey"`
+	OrderId        uuid.UUID      `json:"order_id,omitempty" gorm:"type:uuid;not null"`
+	MerchantId     *uuid.UUID     `json:"merchant_id,omitempty" gorm:"type:uuid"`
+	Status         string         `json:"status,omitempty" gorm:"not null"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	Carrier        string         `json:"carrier,omitempty"`
+	Items          []OrderedItems `json:"items,omitempty" gorm:"foreignKey:ShipmentId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	CreatedAt      time.Time      `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+}
+
+type OrderStatusHistory struct {
+	OrderStatusHistoryId uuid.UUID  `json:"order_status_history_id,omitempty" gorm:"type:uuid;primaryKey"`
+	OrderId              uuid.UUID  `json:"order_id,omitempty" gorm:"type:uuid;not null"`
+	ShipmentId           *uuid.UUID `json:"shipment_id,omitempty" gorm:"type:uuid"`
+	FromStatus           string     `json:"from_status,omitempty"`
+	ToStatus             string     `json:"to_status,omitempty" gorm:"not null"`
+	ChangedBy            uuid.UUID  `json:"changed_by,omitempty" gorm:"type:uuid;not null"`
+	Role                 string     `json:"role,omitempty" gorm:"not null"`
+	CreatedAt            time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
 }
 
 type OrderedItems struct {
-	OrderedItemsId uuid.UUID `json:"ordered_items_id,omitempty" gorm:"type:uuid;primaryKey"`
-	ProductId      uuid.UUID `json:"product_id,omitempty" gorm:"not null"`
-	ProductName    string    `json:"product_name,omitempty" gorm:"not null" `
-	Quantity       uint      `json:"quantity,omitempty" gorm:"not null"`
-	Price          float64   `json:"price,omitempty" gorm:"not null"`
-	OrderId        uuid.UUID `json:"order_id,omitempty"`
+	OrderedItemsId uuid.UUID  `json:"ordered_items_id,omitempty" gorm:"type:uuid;primaryKey"`
+	ProductId      uuid.UUID  `json:"product_id,omitempty" gorm:"not null"`
+	ProductName    string     `json:"product_name,omitempty" gorm:"not null" `
+	Quantity       uint       `json:"quantity,omitempty" gorm:"not null"`
+	Price          float64    `json:"price,omitempty" gorm:"not null"`
+	OrderId        uuid.UUID  `json:"order_id,omitempty"`
+	ShipmentId     *uuid.UUID `json:"shipment_id,omitempty" gorm:"type:uuid"`
+}
+
+type Sessions struct {
+	SessionId         uuid.UUID  `json:"session_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId            uuid.UUID  `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	TwoFactorVerified bool       `json:"two_factor_verified,omitempty" gorm:"not null;default:false"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type RefreshTokens struct {
+	RefreshTokenId uuid.UUID  `json:"refresh_token_id,omitempty" gorm:"type:uuid;primaryKey"`
+	SessionId      uuid.UUID  `json:"session_id,omitempty" gorm:"type:uuid;not null;index"`
+	TokenHash      string     `json:"-" gorm:"unique;not null"`
+	ExpiresAt      time.Time  `json:"expires_at,omitempty" gorm:"not null"`
+	UsedAt         *time.Time `json:"used_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	Session        Sessions   `json:"-" gorm:"foreignKey:SessionId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type PasswordResetTokens struct {
+	PasswordResetTokenId uuid.UUID  `json:"password_reset_token_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId               uuid.UUID  `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	TokenHash            string     `json:"-" gorm:"unique;not null"`
+	ExpiresAt            time.Time  `json:"expires_at,omitempty" gorm:"not null"`
+	UsedAt               *time.Time `json:"used_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type TwoFactorChallenges struct {
+	ChallengeId uuid.UUID  `json:"challenge_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId      uuid.UUID  `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	ExpiresAt   time.Time  `json:"expires_at,omitempty" gorm:"not null"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodeId uuid.UUID  `json:"recovery_code_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId         uuid.UUID  `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	CodeHash       string     `json:"-" gorm:"not null"`
+	UsedAt         *time.Time `json:"used_at,omitempty"`
+}
+
+type TwoFactorPolicies struct {
+	Role      string    `json:"role,omitempty" gorm:"primaryKey;check:role= 'user' or role= 'merchant' or role='admin'"`
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+}
+
+type LoginAttempts struct {
+	AttemptKey   string     `json:"attempt_key,omitempty" gorm:"primaryKey"`
+	Failures     uint       `json:"failures" gorm:"not null;default:0"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+	LastFailedAt time.Time  `json:"last_failed_at,omitempty" gorm:"not null"`
+}
+
+type MerchantApplications struct {
+	ApplicationId       uuid.UUID           `json:"application_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId              uuid.UUID           `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	BusinessName        string              `json:"business_name,omitempty" gorm:"not null"`
+	TaxId               string              `json:"-" gorm:"not null"`
+	TaxIdLast4          string              `json:"tax_id_last4,omitempty" gorm:"-"`
+	PayoutAccountName   string              `json:"payout_account_name,omitempty" gorm:"not null"`
+	PayoutAccountNumber string              `json:"-" gorm:"not null"`
+	PayoutAccountLast4  string              `json:"payout_account_last4,omitempty" gorm:"-"`
+	PayoutRoutingCode   string              `json:"payout_routing_code,omitempty" gorm:"not null"`
+	Status              string              `json:"status,omitempty" gorm:"not null;default:pending;check:status= 'pending' or status= 'approved' or status= 'rejected'"`
+	RejectionReason     string              `json:"rejection_reason,omitempty"`
+	ReviewedBy          *uuid.UUID          `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	ReviewedAt          *time.Time          `json:"reviewed_at,omitempty"`
+	Documents           []MerchantDocuments `json:"documents,omitempty" gorm:"foreignKey:ApplicationId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt           time.Time           `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time           `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+}
+
+type MerchantDocuments struct {
+	DocumentId    uuid.UUID `json:"document_id,omitempty" gorm:"type:uuid;primaryKey"`
+	ApplicationId uuid.UUID `json:"application_id,omitempty" gorm:"type:uuid;not null;index"`
+	FileName      string    `json:"file_name,omitempty" gorm:"not null"`
+	StoragePath   string    `json:"-" gorm:"not null"`
+	ContentType   string    `json:"content_type,omitempty"`
+	Size          int64     `json:"size,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type Reviews struct {
+	ReviewId      uuid.UUID       `json:"review_id,omitempty" gorm:"type:uuid;primaryKey"`
+	ProductId     uuid.UUID       `json:"product_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_reviews_product_user_active,where:deleted_at IS NULL"`
+	UserId        uuid.UUID       `json:"user_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_reviews_product_user_active,where:deleted_at IS NULL"`
+	Stars         uint            `json:"stars,omitempty" gorm:"not null;check:stars >= 1 AND stars <= 5"`
+	Title         string          `json:"title,omitempty" gorm:"not null"`
+	Body          string          `json:"body,omitempty"`
+	MerchantReply string          `json:"merchant_reply,omitempty"`
+	RepliedAt     *time.Time      `json:"replied_at,omitempty"`
+	Status        string          `json:"status,omitempty" gorm:"not null;default:published;check:status= 'published' or status= 'hidden'"`
+	Reports       []ReviewReports `json:"reports,omitempty" gorm:"foreignKey:ReviewId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt     time.Time       `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time       `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt  `json:"-"`
+}
+
+type ReviewReports struct {
+	ReportId   uuid.UUID  `json:"report_id,omitempty" gorm:"type:uuid;primaryKey"`
+	ReviewId   uuid.UUID  `json:"review_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user"`
+	UserId     uuid.UUID  `json:"user_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user"`
+	Reason     string     `json:"reason,omitempty" gorm:"not null"`
+	Status     string     `json:"status,omitempty" gorm:"not null;default:pending;check:status= 'pending' or status= 'resolved'"`
+	ResolvedBy *uuid.UUID `json:"resolved_by,omitempty" gorm:"type:uuid"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type BannedWords struct {
+	Word      string    `json:"word,omitempty" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at,omitempty" gorm:"autoCreateTime"`
+}
+
+type Permissions struct {
+	PermissionName string `json:"permission_name,omitempty" gorm:"primaryKey"`
+	Description    string `json:"description,omitempty"`
+}
+
+type RolePermissions struct {
+	Role           string      `json:"role,omitempty" gorm:"primaryKey;check:role= 'user' or role= 'merchant' or role='admin'"`
+	PermissionName string      `json:"permission_name,omitempty" gorm:"primaryKey"`
+	Permission     Permissions `json:"-" gorm:"foreignKey:PermissionName;references:PermissionName;constraint:OnDelete:CASCADE"`
+}
+
+type Carts struct {
+	CartId    uuid.UUID   `json:"cart_id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserId    uuid.UUID   `json:"user_id,omitempty" gorm:"type:uuid;unique;not null"`
+	Items     []CartItems `json:"items,omitempty" gorm:"foreignKey:CartId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt time.Time   `json:"created_at,omitempty" gorm:"autoCreateTime"`
+	UpdatedAt time.Time   `json:"updated_at,omitempty" gorm:"autoUpdateTime"`
+}
+
+type CartItems struct {
+	CartItemId uuid.UUID `json:"cart_item_id,omitempty" gorm:"type:uuid;primaryKey"`
+	CartId     uuid.UUID `json:"cart_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_cart_product"`
+	ProductId  uuid.UUID `json:"product_id,omitempty" gorm:"type:uuid;not null;uniqueIndex:idx_cart_product"`
+	Quantity   uint      `json:"quantity,omitempty" gorm:"not null"`
+	Product    Products  `json:"-" gorm:"foreignKey:ProductId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (user *Users) BeforeCreate(tx *gorm.DB) error {
@@ -118,3 +317,86 @@ func (orderItem *OrderedItems) BeforeCreate(tx *gorm.DB) error {
 	orderItem.OrderedItemsId = uuid.New()
 	return nil
 }
+
+func (shipment *Shipments) BeforeCreate(tx *gorm.DB) error {
+	shipment.ShipmentId = uuid.New()
+	return nil
+}
+
+func (history *OrderStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	history.OrderStatusHistoryId = uuid.New()
+	return nil
+}
+
+func (session *Sessions) BeforeCreate(tx *gorm.DB) error {
+	session.SessionId = uuid.New()
+	return nil
+}
+
+func (refreshToken *RefreshTokens) BeforeCreate(tx *gorm.DB) error {
+	refreshToken.RefreshTokenId = uuid.New()
+	return nil
+}
+
+func (resetToken *PasswordResetTokens) BeforeCreate(tx *gorm.DB) error {
+	resetToken.PasswordResetTokenId = uuid.New()
+	return nil
+}
+
+func (challenge *TwoFactorChallenges) BeforeCreate(tx *gorm.DB) error {
+	challenge.ChallengeId = uuid.New()
+	return nil
+}
+
+func (recoveryCode *RecoveryCodes) BeforeCreate(tx *gorm.DB) error {
+	recoveryCode.RecoveryCodeId = uuid.New()
+	return nil
+}
+
+func (cart *Carts) BeforeCreate(tx *gorm.DB) error {
+	cart.CartId = uuid.New()
+	return nil
+}
+
+func (cartItem *CartItems) BeforeCreate(tx *gorm.DB) error {
+	cartItem.CartItemId = uuid.New()
+	return nil
+}
+
+func (application *MerchantApplications) BeforeCreate(tx *gorm.DB) error {
+	application.ApplicationId = uuid.New()
+	return nil
+}
+
+func lastFour(value string) string {
+	if len(value) <= 4 {
+		return value
+	}
+
+	return value[len(value)-4:]
+}
+
+func (application *MerchantApplications) AfterCreate(tx *gorm.DB) error {
+	return application.AfterFind(tx)
+}
+
+func (application *MerchantApplications) AfterFind(tx *gorm.DB) error {
+	application.TaxIdLast4 = lastFour(application.TaxId)
+	application.PayoutAccountLast4 = lastFour(application.PayoutAccountNumber)
+	return nil
+}
+
+func (document *MerchantDocuments) BeforeCreate(tx *gorm.DB) error {
+	document.DocumentId = uuid.New()
+	return nil
+}
+
+func (review *Reviews) BeforeCreate(tx *gorm.DB) error {
+	review.ReviewId = uuid.New()
+	return nil
+}
+
+func (report *ReviewReports) BeforeCreate(tx *gorm.DB) error {
+	report.ReportId = uuid.New()
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Storage interface {
+	Save(name string, src io.Reader) (string, error)
+	Open(name string) (string, error)
+	Remove(name string) error
+}
+
+type localStorage struct {
+	root string
+}
+
+func NewStorage() Storage {
+	return NewLocalStorage(os.Getenv("UPLOAD_DIR"))
+}
+
+func NewLocalStorage(root string) Storage {
+	if root == "" {
+		root = "uploads"
+	}
+
+	if !filepath.IsAbs(root) {
+		if workingDir, err := os.Getwd(); err == nil {
+			root = filepath.Join(filepath.Dir(workingDir), root)
+		}
+	}
+
+	return &localStorage{root: root}
+}
+
+func (storage *localStorage) resolve(name string) (string, error) {
+	path := filepath.Join(storage.root, filepath.Clean("/"+name))
+	if !strings.HasPrefix(path, filepath.Clean(storage.root)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid file name: %s", name)
+	}
+
+	return path, nil
+}
+
+func (storage *localStorage) Save(name string, src io.Reader) (string, error) {
+	path, err := storage.resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, src); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return name, nil
+}
+
+func (storage *localStorage) Open(name string) (string, error) {
+	path, err := storage.resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+func (storage *localStorage) Remove(name string) error {
+	path, err := storage.resolve(name)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
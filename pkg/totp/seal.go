@@ -0,0 +1,80 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+const sealedPrefix = "enc:v1:"
+
+var ErrMissingKey = errors.New("TOTP_ENCRYPTION_KEY is not set")
+
+func EncryptionKey() ([]byte, error) {
+	value := os.Getenv("TOTP_ENCRYPTION_KEY")
+	if value == "" {
+		return nil, ErrMissingKey
+	}
+
+	key := sha256.Sum256([]byte(value))
+	return key[:], nil
+}
+
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func SealSecret(secret string, key []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+func OpenSecret(sealed string, key []byte) (string, error) {
+	if !IsSealed(sealed) {
+		return "", errors.New("secret is not sealed")
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return "", errors.New("sealed secret is too short")
+	}
+
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
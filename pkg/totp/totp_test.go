@@ -0,0 +1,71 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{unix: 59, code: "287082"},
+	{unix: 1111111109, code: "081804"},
+	{unix: 1111111111, code: "050471"},
+	{unix: 1234567890, code: "005924"},
+	{unix: 2000000000, code: "279037"},
+	{unix: 20000000000, code: "353130"},
+}
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	for _, vector := range rfc6238Vectors {
+		code, err := GenerateCode(rfc6238Secret, Step(time.Unix(vector.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateCode at %d: %v", vector.unix, err)
+		}
+
+		if code != vector.code {
+			t.Errorf("GenerateCode at %d = %s, want %s", vector.unix, code, vector.code)
+		}
+	}
+}
+
+func TestValidateRFC6238(t *testing.T) {
+	for _, vector := range rfc6238Vectors {
+		at := time.Unix(vector.unix, 0)
+
+		step, ok := Validate(rfc6238Secret, vector.code, at)
+		if !ok || step != Step(at) {
+			t.Errorf("Validate at %d = (%d, %v), want (%d, true)", vector.unix, step, ok, Step(at))
+		}
+
+		if _, ok := Validate(rfc6238Secret, vector.code, at.Add(3*Period*time.Second)); ok {
+			t.Errorf("Validate at %d accepted a code outside the skew window", vector.unix)
+		}
+	}
+}
+
+func TestSealSecret(t *testing.T) {
+	key := make([]byte, 32)
+
+	sealed, err := SealSecret(rfc6238Secret, key)
+	if err != nil {
+		t.Fatalf("SealSecret: %v", err)
+	}
+
+	if !IsSealed(sealed) || sealed == rfc6238Secret {
+		t.Fatalf("SealSecret returned %q, want a sealed value", sealed)
+	}
+
+	secret, err := OpenSecret(sealed, key)
+	if err != nil || secret != rfc6238Secret {
+		t.Fatalf("OpenSecret = (%q, %v), want %q", secret, err, rfc6238Secret)
+	}
+
+	key[0] = 1
+	if _, err := OpenSecret(sealed, key); err == nil {
+		t.Fatal("OpenSecret succeeded with the wrong key")
+	}
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 const (
 	UserRole       = "user"
 	MerchantRole   = "merchant"
@@ -9,4 +11,134 @@ const (
 	OutForDelivery = "out_for_delivery"
 	Delivered      = "delivered"
 	Cancelled      = "cancelled"
+	Pending        = "pending"
+	Approved       = "approved"
+	Rejected       = "rejected"
+	Published      = "published"
+	Hidden         = "hidden"
+	Resolved       = "resolved"
+	SortNewest     = "newest"
+	SortPriceAsc   = "price_asc"
+	SortPriceDesc  = "price_desc"
+	SortRating     = "rating"
+)
+
+const (
+	AccessTokenDuration        = 15 * time.Minute
+	RefreshTokenDuration       = 7 * 24 * time.Hour
+	EmailVerificationDuration  = 24 * time.Hour
+	PasswordResetDuration      = 30 * time.Minute
+	TwoFactorChallengeDuration = 5 * time.Minute
+	LoginBackoffBase           = 30 * time.Second
+	LoginLockoutMax            = 30 * time.Minute
+	LoginAttemptWindow         = time.Hour
+)
+
+const (
+	EmailVerificationPurpose  = "email_verification"
+	TwoFactorChallengePurpose = "two_factor_challenge"
+	TwoFactorIssuer           = "shopping-site"
+	RecoveryCodeCount         = 10
+	AccountAttemptPrefix      = "account:"
+	IPAttemptPrefix           = "ip:"
+	AccountAttemptThreshold   = 5
+	IPAttemptThreshold        = 20
+	MerchantDocumentDir       = "merchant-documents"
+	MaxMerchantDocuments      = 5
+	MaxMerchantDocumentSize   = 5 << 20
+	MaxRequestBodySize        = 32 << 20
+	DefaultPageSize           = 20
+	MaxPageSize               = 100
+	SearchSimilarityThreshold = 0.4
+	ActiveStatus              = "active"
+	SuspendedStatus           = "suspended"
+	DeletedStatus             = "deleted"
+)
+
+var AllowedDocumentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+}
+
+const (
+	ProductRead      = "product:read"
+	ProductWrite     = "product:write"
+	ProductApprove   = "product:approve"
+	CartManage       = "cart:manage"
+	OrderPlace       = "order:place"
+	OrderRead        = "order:read"
+	OrderCancel      = "order:cancel"
+	OrderShip        = "order:ship"
+	OrderManage      = "order:manage"
+	CatalogManage    = "catalog:manage"
+	ProfileManage    = "profile:manage"
+	SecurityManage   = "security:manage"
+	PermissionManage = "permission:manage"
+	MerchantApply    = "merchant:apply"
+	MerchantReview   = "merchant:review"
+	UserManage       = "user:manage"
+	AddressManage    = "address:manage"
+	ReviewWrite      = "review:write"
+	ReviewReply      = "review:reply"
+	ReviewReport     = "review:report"
+	ReviewModerate   = "review:moderate"
 )
+
+var PermissionRegistry = map[string]string{
+	ProductRead:      "browse approved products",
+	ProductWrite:     "create and maintain own products",
+	ProductApprove:   "review products awaiting approval",
+	CartManage:       "manage own cart",
+	OrderPlace:       "place orders",
+	OrderRead:        "view own orders",
+	OrderCancel:      "cancel own orders",
+	OrderShip:        "view and fulfil orders for own products",
+	OrderManage:      "update the status of any order",
+	CatalogManage:    "manage categories and brands",
+	ProfileManage:    "update own profile and password",
+	SecurityManage:   "manage two-factor policy and account lockouts",
+	PermissionManage: "manage role permissions",
+	MerchantApply:    "apply to become a merchant",
+	MerchantReview:   "review merchant applications",
+	UserManage:       "search, suspend, delete and change roles of accounts",
+	AddressManage:    "manage own address book",
+	ReviewWrite:      "review delivered products",
+	ReviewReply:      "reply to reviews on own products",
+	ReviewReport:     "report abusive reviews",
+	ReviewModerate:   "moderate reported reviews and banned words",
+}
+
+var DefaultRolePermissions = map[string][]string{
+	UserRole:     {ProductRead, CartManage, OrderPlace, OrderRead, OrderCancel, ProfileManage, MerchantApply, AddressManage, ReviewWrite, ReviewReport},
+	MerchantRole: {ProductWrite, OrderShip, ProfileManage, ReviewReply},
+	AdminRole:    {ProductApprove, OrderManage, CatalogManage, SecurityManage, PermissionManage, MerchantReview, UserManage, ReviewModerate},
+}
+
+var OrderStatusTransitions = map[string]map[string][]string{
+	Placed: {
+		Shipped:   {MerchantRole, AdminRole},
+		Cancelled: {UserRole, MerchantRole, AdminRole},
+	},
+	Shipped: {
+		OutForDelivery: {MerchantRole, AdminRole},
+	},
+	OutForDelivery: {
+		Delivered: {MerchantRole, AdminRole},
+	},
+}
+
+var OrderStatusSequence = []string{Placed, Shipped, OutForDelivery, Delivered}
+
+var PriceBuckets = []float64{500, 1000, 5000, 10000, 50000}
+
+var ProductSorts = []string{SortNewest, SortPriceAsc, SortPriceDesc, SortRating}
+
+var States = []string{
+	"Andhra Pradesh", "Arunachal Pradesh", "Assam", "Bihar", "Chhattisgarh", "Goa", "Gujarat", "Haryana",
+	"Himachal Pradesh", "Jharkhand", "Karnataka", "Kerala", "Madhya Pradesh", "Maharashtra", "Manipur",
+	"Meghalaya", "Mizoram", "Nagaland", "Odisha", "Punjab", "Rajasthan", "Sikkim", "Tamil Nadu", "Telangana",
+	"Tripura", "Uttar Pradesh", "Uttarakhand", "West Bengal", "Andaman and Nicobar Islands", "Chandigarh",
+	"Dadra and Nagar Haveli and Daman and Diu", "Delhi", "Jammu and Kashmir", "Ladakh", "Lakshadweep", "Puducherry",
+}
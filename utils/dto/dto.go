@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"shopping-site/pkg/models"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -12,18 +15,247 @@ type ResponseJson struct {
 }
 
 type ErrorResponse struct {
-	Error  string `json:"error"`
-	Status int    `json:"status"`
+	Error  string      `json:"error"`
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	ReturnToken bool   `json:"return_token"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 type JWTClaims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	SessionId uuid.UUID `json:"session_id"`
+	jwt.RegisteredClaims
+}
+
+type PurposeTokenClaims struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email"`
+	Purpose string    `json:"purpose"`
 	jwt.RegisteredClaims
 }
+
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+type PasswordResetRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ProfileUpdateRequest struct {
+	FirstName *string            `json:"first_name"`
+	LastName  *string            `json:"last_name"`
+	Email     *string            `json:"email"`
+	Phone     *string            `json:"phone"`
+	Address   []models.Addresses `json:"address"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+	ReturnToken    bool   `json:"return_token"`
+}
+
+type TwoFactorPolicyRequest struct {
+	Role     string `json:"role"`
+	Required *bool  `json:"required"`
+}
+
+type RolePermissionRequest struct {
+	Permission string `json:"permission"`
+}
+
+type PermissionsResponse struct {
+	Permissions interface{}         `json:"permissions"`
+	Roles       map[string][]string `json:"roles"`
+}
+
+type MerchantApplicationRequest struct {
+	BusinessName        string `form:"business_name"`
+	TaxId               string `form:"tax_id"`
+	PayoutAccountName   string `form:"payout_account_name"`
+	PayoutAccountNumber string `form:"payout_account_number"`
+	PayoutRoutingCode   string `form:"payout_routing_code"`
+}
+
+type AuthTokens struct {
+	AccessToken      string    `json:"access_token,omitempty"`
+	AccessExpiresAt  time.Time `json:"access_expires_at,omitempty"`
+	RefreshToken     string    `json:"refresh_token,omitempty"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitempty"`
+}
+
+type CartItemRequest struct {
+	ProductId uuid.UUID `json:"product_id"`
+	Quantity  uint      `json:"quantity"`
+}
+
+type CheckoutRequest struct {
+	AddressId uuid.UUID `json:"address_id"`
+}
+
+type CartItemResponse struct {
+	ProductId   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Price       float64   `json:"price"`
+	Quantity    uint      `json:"quantity"`
+	Amount      float64   `json:"amount"`
+}
+
+type CartResponse struct {
+	CartId      uuid.UUID          `json:"cart_id"`
+	Items       []CartItemResponse `json:"items"`
+	TotalAmount float64            `json:"total_amount"`
+}
+
+type StockRequest struct {
+	Stock *uint `json:"stock"`
+}
+
+type AddressUpdateRequest struct {
+	DoorNo            *string `json:"door_no"`
+	Street            *string `json:"street"`
+	City              *string `json:"city"`
+	State             *string `json:"state"`
+	ZipCode           *uint   `json:"zip_code"`
+	IsDefaultShipping *bool   `json:"is_default_shipping"`
+	IsDefaultBilling  *bool   `json:"is_default_billing"`
+}
+
+type CategoryUpdateRequest struct {
+	CategoryName *string `json:"category_name"`
+	Slug         *string `json:"slug"`
+	ParentId     *string `json:"parent_id"`
+}
+
+type BrandUpdateRequest struct {
+	BrandName *string `json:"brand_name"`
+	Slug      *string `json:"slug"`
+}
+
+type OrderFilter struct {
+	Status string
+	From   time.Time
+	To     time.Time
+}
+
+type UserFilter struct {
+	Search string `query:"search"`
+	Role   string `query:"role"`
+	Status string `query:"status"`
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+}
+
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	Total      int64       `json:"total"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	Facets     interface{} `json:"facets,omitempty"`
+}
+
+type SuspendRequest struct {
+	Reason string `json:"reason"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+type ShipmentUpdate struct {
+	Status         string
+	TrackingNumber string
+	Carrier        string
+}
+
+type RejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply"`
+}
+
+type ReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+type BannedWordRequest struct {
+	Word string `json:"word"`
+}
+
+type ProductSearchFilter struct {
+	Query string `query:"q"`
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+}
+
+type ProductSearchResult struct {
+	models.Products        `gorm:"embedded"`
+	Relevance              float64 `json:"relevance"`
+	HighlightedName        string  `json:"highlighted_name,omitempty"`
+	HighlightedDescription string  `json:"highlighted_description,omitempty"`
+}
+
+type ProductFilter struct {
+	Brand        string    `query:"brand"`
+	BrandName    string    `query:"brand_name"`
+	Category     string    `query:"category"`
+	CategoryName string    `query:"category_name"`
+	MinPrice     float64   `query:"min_price"`
+	MaxPrice     float64   `query:"max_price"`
+	Rating       float32   `query:"rating"`
+	Merchant     string    `query:"merchant_id"`
+	Status       string    `query:"status"`
+	Sort         string    `query:"sort"`
+	Cursor       string    `query:"cursor"`
+	Page         int       `query:"page"`
+	Limit        int       `query:"limit"`
+	Facets       bool      `query:"facets"`
+	MerchantId   uuid.UUID `query:"-"`
+	ApprovedOnly bool      `query:"-"`
+}
+
+type FacetCount struct {
+	Id    *uuid.UUID `json:"id,omitempty"`
+	Label string     `json:"label"`
+	Min   *float64   `json:"min,omitempty"`
+	Max   *float64   `json:"max,omitempty"`
+	Count int64      `json:"count"`
+}
+
+type ProductFacets struct {
+	Brands     []FacetCount `json:"brands"`
+	Categories []FacetCount `json:"categories"`
+	Prices     []FacetCount `json:"prices"`
+	Ratings    []FacetCount `json:"ratings"`
+}